@@ -0,0 +1,85 @@
+// Command booker is the booking half of the queue-based scraper/booker
+// split: it consumes BookingIntents from a Queue and calls
+// BookTimeSlot/AddPlayingPartner, and is the only process that needs to
+// hold Twilio/notifier credentials.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/stebennett/tee-sniper/pkg/api"
+	"github.com/stebennett/tee-sniper/pkg/booker"
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/process"
+	"github.com/stebennett/tee-sniper/pkg/queue"
+)
+
+type bookerArgs struct {
+	Username string `short:"u" long:"username" required:"true" description:"The username to use for booking"`
+	Pin      string `short:"p" long:"pin" required:"true" description:"The pin associated with the username for booking"`
+	BaseUrl  string `short:"b" long:"baseurl" required:"true" description:"The host for the booking website"`
+	DryRun   bool   `short:"x" long:"dryrun" description:"Run everything, but don't do the booking and assume it succeeds"`
+
+	Notifier     string `short:"o" long:"notifier" default:"twilio" description:"Which notification backend(s) to use: twilio, ntfy, or both"`
+	FromNumber   string `short:"f" long:"fromnumber" description:"The number to send the confirmation SMS from"`
+	NtfyTopicURL string `long:"ntfytopicurl" description:"The ntfy.sh (or self-hosted) topic URL to publish booking notifications to, required when notifier is ntfy or both"`
+
+	VerifyServiceSid      string `long:"verifyservicesid" description:"Twilio Verify Service SID; when set, SendSms refuses to message a number until it has completed opt-in verification"`
+	VerificationStorePath string `long:"verificationstorepath" default:"verification_store.json" description:"Path to the local file recording which numbers have completed opt-in verification"`
+
+	DailyLimitPerRecipient int    `long:"dailylimitperrecipient" default:"10" description:"The maximum number of notifications to send a single recipient per rolling 24h window"`
+	RateLimitStorePath     string `long:"ratelimitstorepath" default:"rate_limit_store.json" description:"Path to the local file tracking notifications sent per recipient for rate limiting"`
+
+	BookingStorePath string `long:"bookingstorepath" default:"bookings.json" description:"Path to the local file recording past booking confirmations, shared with tee-sniper-api's GET /v1/bookings"`
+}
+
+func main() {
+	var a bookerArgs
+	if _, err := flags.NewParser(&a, flags.Default).Parse(); err != nil {
+		log.Fatal(err)
+	}
+
+	bookingClient, err := clients.NewBookingClient(a.BaseUrl)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	notifiers := clients.NotifiersFromConfig(clients.NotifierConfig{
+		Notifier:               a.Notifier,
+		FromNumber:             a.FromNumber,
+		NtfyTopicURL:           a.NtfyTopicURL,
+		VerifyServiceSid:       a.VerifyServiceSid,
+		VerificationStorePath:  a.VerificationStorePath,
+		DailyLimitPerRecipient: a.DailyLimitPerRecipient,
+		RateLimitStorePath:     a.RateLimitStorePath,
+	})
+
+	bookings, err := api.NewBookingStore(a.BookingStorePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	state := &booker.State{
+		BookingClient: bookingClient,
+		Notifiers:     notifiers,
+		// EmbeddedQueue only coordinates Components within this process.
+		// Point this at a networked Queue implementation (NATS, Redis
+		// Streams) once cmd/scraper needs to run on a different host.
+		Queue:    queue.NewEmbeddedQueue(),
+		Username: a.Username,
+		Pin:      a.Pin,
+		DryRun:   a.DryRun,
+		OnBooked: func(bookingID, date, teeTime string) {
+			if err := bookings.Record(api.Booking{ID: bookingID, CourseURL: a.BaseUrl, Date: date, Time: teeTime, BookedAt: time.Now()}); err != nil {
+				log.Printf("Failed to record booking %s: %s", bookingID, err.Error())
+			}
+		},
+	}
+
+	if err := process.MakeApp(state).Run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,58 @@
+// Command scraper is the scrape-only half of the queue-based
+// scraper/booker split: it logs into the booking site and publishes
+// AvailabilityEvents, leaving actual booking (and the Twilio/notifier
+// credentials that requires) to cmd/booker.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/process"
+	"github.com/stebennett/tee-sniper/pkg/queue"
+	"github.com/stebennett/tee-sniper/pkg/scraper"
+)
+
+type scraperArgs struct {
+	DaysAhead int    `short:"d" long:"days" required:"true" description:"The number of days ahead to look for a tee-slot"`
+	Username  string `short:"u" long:"username" required:"true" description:"The username to use for booking"`
+	Pin       string `short:"p" long:"pin" required:"true" description:"The pin associated with the username for booking"`
+	BaseUrl   string `short:"b" long:"baseurl" required:"true" description:"The host for the booking website"`
+
+	Course       string        `long:"course" description:"Label attached to published availability events, for deployments scraping more than one course"`
+	PollInterval time.Duration `long:"pollinterval" default:"30s" description:"How often to poll the booking site for availability"`
+}
+
+func main() {
+	var a scraperArgs
+	if _, err := flags.NewParser(&a, flags.Default).Parse(); err != nil {
+		log.Fatal(err)
+	}
+
+	bookingClient, err := clients.NewBookingClient(a.BaseUrl)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dateStr := time.Now().AddDate(0, 0, a.DaysAhead).Format("02-01-2006")
+
+	state := &scraper.State{
+		BookingClient: bookingClient,
+		// EmbeddedQueue only coordinates Components within this process.
+		// Point this at a networked Queue implementation (NATS, Redis
+		// Streams) once cmd/booker needs to run on a different host.
+		Queue:        queue.NewEmbeddedQueue(),
+		Username:     a.Username,
+		Pin:          a.Pin,
+		Course:       a.Course,
+		DateStr:      dateStr,
+		PollInterval: a.PollInterval,
+	}
+
+	if err := process.MakeApp(state).Run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
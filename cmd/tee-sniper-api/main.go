@@ -0,0 +1,66 @@
+// Command tee-sniper-api runs the HTTP control plane for scheduling
+// snipes and reviewing past bookings (see pkg/api), backed by an
+// api.Runner that polls every stored snipe for a bookable slot and drives
+// the booking itself, so tee-sniper can run as a long-lived service
+// driven by tee-sniper-ctl instead of a single cron-triggered one-shot.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/stebennett/tee-sniper/pkg/api"
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+type apiArgs struct {
+	Addr  string `short:"a" long:"addr" default:":8090" description:"The address to listen on"`
+	Token string `long:"token" required:"true" description:"The bearer token required on mutating requests (POST /v1/snipes, DELETE /v1/snipes/{id})"`
+
+	SnipeStorePath   string `long:"snipestorepath" default:"snipes.json" description:"Path to the local file persisting scheduled snipes"`
+	BookingStorePath string `long:"bookingstorepath" default:"bookings.json" description:"Path to the local file persisting past booking confirmations"`
+
+	Username string `short:"u" long:"username" required:"true" description:"The username to use for booking"`
+	Pin      string `short:"p" long:"pin" required:"true" description:"The pin associated with the username for booking"`
+
+	PollInterval time.Duration `long:"pollinterval" default:"1m" description:"How often the runner checks every scheduled snipe for a bookable slot"`
+}
+
+func main() {
+	var a apiArgs
+	if _, err := flags.NewParser(&a, flags.Default).Parse(); err != nil {
+		log.Fatal(err)
+	}
+
+	snipes, err := api.NewSnipeStore(a.SnipeStorePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bookings, err := api.NewBookingStore(a.BookingStorePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server := api.NewServer(snipes, bookings, a.Token)
+
+	runner := &api.Runner{
+		Snipes:       snipes,
+		Bookings:     bookings,
+		Client:       func(courseURL string) (clients.BookingService, error) { return clients.NewBookingClient(courseURL) },
+		Username:     a.Username,
+		Pin:          a.Pin,
+		PollInterval: a.PollInterval,
+	}
+	go func() {
+		if err := runner.Run(context.Background()); err != nil {
+			log.Printf("tee-sniper-api: runner stopped: %s", err.Error())
+		}
+	}()
+
+	log.Printf("tee-sniper-api listening on %s", a.Addr)
+	log.Fatal(http.ListenAndServe(a.Addr, server.Handler()))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/stebennett/tee-sniper/pkg/api"
+)
+
+// scheduleArgs are the flags for `tee-sniper-ctl schedule`.
+type scheduleArgs struct {
+	Addr     string `long:"addr" default:"http://localhost:8090" description:"The tee-sniper-api server address"`
+	Token    string `long:"token" required:"true" description:"The bearer token configured on the tee-sniper-api server"`
+	Course   string `long:"course" required:"true" description:"The course URL to watch"`
+	Date     string `long:"date" required:"true" description:"The date to watch, e.g. 15-01-2025"`
+	Between  string `long:"between" required:"true" description:"The earliest-latest time window to book within, e.g. 08:00-10:00"`
+	Partners string `long:"partners" description:"Comma-separated playing partner IDs"`
+	Notify   bool   `long:"notify" description:"Send a notification when this snipe books a slot"`
+}
+
+// runSchedule sends POST /v1/snipes.
+func runSchedule(args []string) error {
+	var a scheduleArgs
+	if _, err := flags.NewParser(&a, flags.Default).ParseArgs(args); err != nil {
+		return err
+	}
+
+	earliest, latest, err := splitWindow(a.Between)
+	if err != nil {
+		return err
+	}
+
+	client := apiClient{addr: a.Addr, token: a.Token}
+
+	req := struct {
+		CourseURL string   `json:"course_url"`
+		Date      string   `json:"date"`
+		Earliest  string   `json:"earliest"`
+		Latest    string   `json:"latest"`
+		Partners  []string `json:"partners"`
+		Notify    bool     `json:"notify"`
+	}{
+		CourseURL: a.Course,
+		Date:      a.Date,
+		Earliest:  earliest,
+		Latest:    latest,
+		Partners:  splitPartners(a.Partners),
+		Notify:    a.Notify,
+	}
+
+	var snipe api.Snipe
+	if err := client.do("POST", "/v1/snipes", req, &snipe); err != nil {
+		return err
+	}
+
+	fmt.Printf("Scheduled snipe %s: %s on %s between %s and %s\n", snipe.ID, snipe.CourseURL, snipe.Date, snipe.Earliest, snipe.Latest)
+	return nil
+}
+
+// listArgs are the flags for `tee-sniper-ctl list`.
+type listArgs struct {
+	Addr string `long:"addr" default:"http://localhost:8090" description:"The tee-sniper-api server address"`
+}
+
+// runList sends GET /v1/snipes.
+func runList(args []string) error {
+	var a listArgs
+	if _, err := flags.NewParser(&a, flags.Default).ParseArgs(args); err != nil {
+		return err
+	}
+
+	client := apiClient{addr: a.Addr}
+
+	var snipes []api.Snipe
+	if err := client.do("GET", "/v1/snipes", nil, &snipes); err != nil {
+		return err
+	}
+
+	if len(snipes) == 0 {
+		fmt.Println("No snipes scheduled")
+		return nil
+	}
+
+	for _, snipe := range snipes {
+		fmt.Printf("%s: %s on %s between %s and %s\n", snipe.ID, snipe.CourseURL, snipe.Date, snipe.Earliest, snipe.Latest)
+	}
+	return nil
+}
+
+// cancelArgs are the flags for `tee-sniper-ctl cancel`.
+type cancelArgs struct {
+	Addr  string `long:"addr" default:"http://localhost:8090" description:"The tee-sniper-api server address"`
+	Token string `long:"token" required:"true" description:"The bearer token configured on the tee-sniper-api server"`
+	ID    string `long:"id" required:"true" description:"The ID of the snipe to cancel"`
+}
+
+// runCancel sends DELETE /v1/snipes/{id}.
+func runCancel(args []string) error {
+	var a cancelArgs
+	if _, err := flags.NewParser(&a, flags.Default).ParseArgs(args); err != nil {
+		return err
+	}
+
+	client := apiClient{addr: a.Addr, token: a.Token}
+
+	if err := client.do("DELETE", "/v1/snipes/"+a.ID, nil, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelled snipe %s\n", a.ID)
+	return nil
+}
+
+// bookingsArgs are the flags for `tee-sniper-ctl bookings`.
+type bookingsArgs struct {
+	Addr string `long:"addr" default:"http://localhost:8090" description:"The tee-sniper-api server address"`
+}
+
+// runBookings sends GET /v1/bookings.
+func runBookings(args []string) error {
+	var a bookingsArgs
+	if _, err := flags.NewParser(&a, flags.Default).ParseArgs(args); err != nil {
+		return err
+	}
+
+	client := apiClient{addr: a.Addr}
+
+	var bookings []api.Booking
+	if err := client.do("GET", "/v1/bookings", nil, &bookings); err != nil {
+		return err
+	}
+
+	if len(bookings) == 0 {
+		fmt.Println("No bookings yet")
+		return nil
+	}
+
+	for _, booking := range bookings {
+		fmt.Printf("%s: %s on %s at %s\n", booking.ID, booking.CourseURL, booking.Date, booking.Time)
+	}
+	return nil
+}
+
+// splitWindow parses a "08:00-10:00" style flag into its earliest/latest
+// halves.
+func splitWindow(window string) (earliest, latest string, err error) {
+	parts := strings.SplitN(window, "-", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid --between %q, expected EARLIEST-LATEST e.g. 08:00-10:00", window)
+	}
+	return parts[0], parts[1], nil
+}
+
+// splitPartners parses a comma-separated --partners flag, returning nil
+// for an empty string.
+func splitPartners(partners string) []string {
+	if partners == "" {
+		return nil
+	}
+	return strings.Split(partners, ",")
+}
@@ -0,0 +1,39 @@
+// Command tee-sniper-ctl is a companion CLI for tee-sniper-api, letting
+// household members schedule and cancel snipes and review past bookings
+// without touching the server directly:
+//
+//	tee-sniper-ctl schedule --course https://example.com --date 15-01-2025 --between 08:00-10:00
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run dispatches the tee-sniper-ctl subcommands. args is os.Args[1:].
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: tee-sniper-ctl <schedule|list|cancel|bookings> [options]")
+	}
+
+	switch args[0] {
+	case "schedule":
+		return runSchedule(args[1:])
+	case "list":
+		return runList(args[1:])
+	case "cancel":
+		return runCancel(args[1:])
+	case "bookings":
+		return runBookings(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
@@ -0,0 +1,94 @@
+// Command tee-sniper-server runs the long-lived booking service (see
+// pkg/bookingserver) over HTTP/JSON, so the same BookingClient that
+// powers the one-shot CLI can also back repeated CreateBooking,
+// UpdateBooking, and CancelBooking RPCs from another system.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/stebennett/tee-sniper/pkg/bookingserver"
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+type serverArgs struct {
+	Addr string `short:"a" long:"addr" default:":8091" description:"The address to listen on"`
+
+	Username string `short:"u" long:"username" required:"true" description:"The username to use for booking"`
+	Pin      string `short:"p" long:"pin" required:"true" description:"The pin associated with the username for booking"`
+	BaseUrl  string `short:"b" long:"baseurl" required:"true" description:"The host for the booking website"`
+
+	HistoryStorePath string `long:"historystorepath" default:"booking_history.json" description:"Path to the local file persisting booking history"`
+
+	FromNumber string `short:"f" long:"fromnumber" description:"The number to send booking-event SMS from; required when notifier is twilio or both"`
+	ToNumber   string `short:"n" long:"tonumber" description:"The number to send booking-event SMS to; required when notifier is twilio or both"`
+
+	Notifier     string `long:"notifier" default:"twilio" description:"Which notification backend(s) to publish booking events to: twilio, ntfy, or both"`
+	NtfyTopicURL string `long:"ntfytopicurl" description:"The ntfy.sh (or self-hosted) topic URL to publish booking events to, required when notifier is ntfy or both"`
+
+	VerifyServiceSid      string `long:"verifyservicesid" description:"Twilio Verify Service SID; when set, notifications refuse to message a number until it has completed opt-in verification"`
+	VerificationStorePath string `long:"verificationstorepath" default:"verification_store.json" description:"Path to the local file recording which numbers have completed opt-in verification"`
+
+	DailyLimitPerRecipient int    `long:"dailylimitperrecipient" default:"10" description:"The maximum number of notifications to send a single recipient per rolling 24h window"`
+	RateLimitStorePath     string `long:"ratelimitstorepath" default:"rate_limit_store.json" description:"Path to the local file tracking notifications sent per recipient for rate limiting"`
+
+	DryRun bool `short:"x" long:"dryrun" description:"Log booking-event notifications instead of actually sending them"`
+}
+
+// notifierConfigFrom adapts a to clients.NotifierConfig, mirroring
+// cmd/tee-sniper's helper of the same name so both binaries build their
+// Notifier fan-out through clients.NotifiersFromConfig the same way.
+func notifierConfigFrom(a serverArgs) clients.NotifierConfig {
+	return clients.NotifierConfig{
+		Notifier:               a.Notifier,
+		FromNumber:             a.FromNumber,
+		NtfyTopicURL:           a.NtfyTopicURL,
+		VerifyServiceSid:       a.VerifyServiceSid,
+		VerificationStorePath:  a.VerificationStorePath,
+		DailyLimitPerRecipient: a.DailyLimitPerRecipient,
+		RateLimitStorePath:     a.RateLimitStorePath,
+	}
+}
+
+// sinkFromArgs builds the bookingserver.Sink notified of every booking
+// outcome, fanning out across every notifier a selects (see
+// clients.NotifiersFromConfig) via bookingserver.MultiSink.
+func sinkFromArgs(a serverArgs) bookingserver.Sink {
+	notifiers := clients.NotifiersFromConfig(notifierConfigFrom(a))
+
+	sinks := make([]bookingserver.Sink, len(notifiers))
+	for i, n := range notifiers {
+		sinks[i] = bookingserver.NotifierSink{Notifier: n, Recipient: a.ToNumber, DryRun: a.DryRun}
+	}
+	return bookingserver.MultiSink{Sinks: sinks}
+}
+
+func main() {
+	var a serverArgs
+	if _, err := flags.NewParser(&a, flags.Default).Parse(); err != nil {
+		log.Fatal(err)
+	}
+
+	bookingClient, err := clients.NewBookingClient(a.BaseUrl)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := bookingClient.LoginContext(context.Background(), a.Username, a.Pin); err != nil {
+		log.Fatal(err)
+	}
+
+	history, err := bookingserver.NewHistoryStore(a.HistoryStorePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := bookingserver.NewService(bookingClient, history, sinkFromArgs(a))
+	server := bookingserver.NewServer(service)
+
+	log.Printf("tee-sniper-server listening on %s", a.Addr)
+	log.Fatal(http.ListenAndServe(a.Addr, server.Handler()))
+}
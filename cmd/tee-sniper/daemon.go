@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/stebennett/tee-sniper/pkg/bookingpool"
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/config"
+	"github.com/stebennett/tee-sniper/pkg/logger"
+	"github.com/stebennett/tee-sniper/pkg/scheduler"
+	"github.com/stebennett/tee-sniper/pkg/store"
+	"github.com/stebennett/tee-sniper/pkg/utils"
+)
+
+// runDaemonCommand dispatches `tee-sniper daemon run|list-runs|skip-next|trigger-now`.
+// args is os.Args[2:], i.e. everything after "daemon".
+//
+// skip-next and trigger-now are implemented as separate, short-lived CLI
+// invocations against the same RunStorePath a running `daemon run`
+// process reads, rather than a live signal sent to that process - this
+// repo has no IPC mechanism between tee-sniper processes to build on, and
+// a shared on-disk store keeps these admin commands usable even when run
+// from a different shell (or host, for a networked store path) than the
+// daemon itself.
+func runDaemonCommand(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: tee-sniper daemon <run|list-runs|skip-next|trigger-now> [options]")
+	}
+
+	switch args[0] {
+	case "run":
+		return runDaemonRun(args[1:])
+	case "list-runs":
+		return runDaemonListRuns(args[1:])
+	case "skip-next":
+		return runDaemonSkipNext(args[1:])
+	case "trigger-now":
+		return runDaemonTriggerNow(args[1:])
+	default:
+		return fmt.Errorf("unknown daemon subcommand %q", args[0])
+	}
+}
+
+// runDaemonRun parses args as a full Config (see config.GetConfigFromArgs)
+// and blocks, firing a booking attempt once a day at DaemonTriggerTime
+// until the process is killed, recording every outcome to RunStorePath.
+func runDaemonRun(args []string) error {
+	conf, err := config.GetConfigFromArgs(args)
+	if errors.Is(err, config.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Init(conf.LogLevel, conf.LogFormat, conf.LogLabels)
+
+	var pool *bookingpool.Pool
+	if conf.ConfigFilePath == "" {
+		pool, err = poolFromConfig(conf)
+		if err != nil {
+			return err
+		}
+	}
+
+	app := NewApp(conf, pool, clients.NewTwilioVoiceClient(), clients.NotifiersFromConfig(notifierConfigFrom(conf))...)
+
+	runOnce := app.runOnce
+	if conf.ConfigFilePath != "" {
+		courses, err := coursesFromConfig(conf)
+		if err != nil {
+			return err
+		}
+		runOnce = func() (string, error) {
+			return "", app.RunCourses(context.Background(), courses)
+		}
+	}
+
+	runStore, err := store.NewRunStore(conf.RunStorePath)
+	if err != nil {
+		return err
+	}
+	defer runStore.Close()
+
+	loc, err := time.LoadLocation(conf.DaemonTimezone)
+	if err != nil {
+		return err
+	}
+
+	clock, err := utils.NewRealClock(conf.DaemonTimezone)
+	if err != nil {
+		return err
+	}
+
+	sched := &scheduler.Scheduler{
+		Clock:      clock,
+		ShouldSkip: runStore.SkipNext,
+	}
+	trigger := scheduler.Trigger{TimeOfDay: conf.DaemonTriggerTime, Location: loc}
+
+	log.Printf("daemon: waiting for next trigger at %s daily (%s)", conf.DaemonTriggerTime, conf.DaemonTimezone)
+
+	return sched.Run(context.Background(), trigger, runOnce, func(r scheduler.Result) {
+		if r.Skipped {
+			log.Printf("daemon: skipping scheduled run at %s (skip-next requested)", r.StartedAt.Format(time.RFC3339))
+		}
+
+		record := store.RunRecord{
+			StartedAt:  r.StartedAt,
+			FinishedAt: r.FinishedAt,
+			Success:    !r.Skipped && r.Err == nil,
+			BookingID:  r.BookingID,
+		}
+		if r.Err != nil {
+			record.Err = r.Err.Error()
+		}
+
+		if err := runStore.RecordRun(record); err != nil {
+			log.Printf("daemon: failed to persist run history: %s", err.Error())
+		}
+	})
+}
+
+// daemonAdminArgs are the flags shared by list-runs and skip-next - just
+// enough Config to find the same RunStorePath a `daemon run` process was
+// started with. trigger-now parses a full Config instead, since it also
+// builds an App.
+type daemonAdminArgs struct {
+	RunStorePath string `long:"runstorepath" default:"run_history.db" description:"Path to the BoltDB file 'daemon run' records its run history to"`
+}
+
+// runDaemonListRuns prints every persisted RunRecord, oldest first.
+func runDaemonListRuns(args []string) error {
+	var a daemonAdminArgs
+	if _, err := flags.NewParser(&a, flags.Default).ParseArgs(args); err != nil {
+		return err
+	}
+
+	runStore, err := store.NewRunStore(a.RunStorePath)
+	if err != nil {
+		return err
+	}
+	defer runStore.Close()
+
+	runs, err := runStore.ListRuns()
+	if err != nil {
+		return err
+	}
+
+	if len(runs) == 0 {
+		fmt.Println("no runs recorded yet")
+		return nil
+	}
+
+	for _, r := range runs {
+		status := "failed"
+		if r.Success {
+			status = "succeeded"
+		}
+		fmt.Printf("%s -> %s: %s", r.StartedAt.Format(time.RFC3339), r.FinishedAt.Format(time.RFC3339), status)
+		if r.BookingID != "" {
+			fmt.Printf(" (booking ID: %s)", r.BookingID)
+		}
+		if r.Err != "" {
+			fmt.Printf(" (%s)", r.Err)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// runDaemonSkipNext marks the next scheduled trigger of a `daemon run`
+// process reading the same RunStorePath to be skipped.
+func runDaemonSkipNext(args []string) error {
+	var a daemonAdminArgs
+	if _, err := flags.NewParser(&a, flags.Default).ParseArgs(args); err != nil {
+		return err
+	}
+
+	runStore, err := store.NewRunStore(a.RunStorePath)
+	if err != nil {
+		return err
+	}
+	defer runStore.Close()
+
+	if err := runStore.SetSkipNext(true); err != nil {
+		return err
+	}
+
+	fmt.Println("next scheduled run will be skipped")
+	return nil
+}
+
+// runDaemonTriggerNow fires a booking attempt immediately, exactly as
+// `daemon run` would at its next scheduled trigger, recording the outcome
+// to the same RunStorePath.
+func runDaemonTriggerNow(args []string) error {
+	conf, err := config.GetConfigFromArgs(args)
+	if errors.Is(err, config.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Init(conf.LogLevel, conf.LogFormat, conf.LogLabels)
+
+	var pool *bookingpool.Pool
+	if conf.ConfigFilePath == "" {
+		pool, err = poolFromConfig(conf)
+		if err != nil {
+			return err
+		}
+	}
+
+	app := NewApp(conf, pool, clients.NewTwilioVoiceClient(), clients.NotifiersFromConfig(notifierConfigFrom(conf))...)
+
+	runOnce := app.runOnce
+	if conf.ConfigFilePath != "" {
+		courses, err := coursesFromConfig(conf)
+		if err != nil {
+			return err
+		}
+		runOnce = func() (string, error) {
+			return "", app.RunCourses(context.Background(), courses)
+		}
+	}
+
+	runStore, err := store.NewRunStore(conf.RunStorePath)
+	if err != nil {
+		return err
+	}
+	defer runStore.Close()
+
+	startedAt := app.TimeNow()
+	bookingID, runErr := runOnce()
+	finishedAt := app.TimeNow()
+
+	record := store.RunRecord{StartedAt: startedAt, FinishedAt: finishedAt, Success: runErr == nil, BookingID: bookingID}
+	if runErr != nil {
+		record.Err = runErr.Error()
+	}
+	if err := runStore.RecordRun(record); err != nil {
+		log.Printf("daemon: failed to persist run history: %s", err.Error())
+	}
+
+	return runErr
+}
@@ -1,14 +1,27 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stebennett/tee-sniper/pkg/bookingfsm"
+	"github.com/stebennett/tee-sniper/pkg/bookingpool"
 	"github.com/stebennett/tee-sniper/pkg/clients"
+	clientsmetrics "github.com/stebennett/tee-sniper/pkg/clients/metrics"
 	"github.com/stebennett/tee-sniper/pkg/config"
+	"github.com/stebennett/tee-sniper/pkg/logger"
+	"github.com/stebennett/tee-sniper/pkg/metrics"
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stebennett/tee-sniper/pkg/notify"
 	"github.com/stebennett/tee-sniper/pkg/teetimes"
 )
 
@@ -16,147 +29,582 @@ var (
 	ErrNoBooking = errors.New("failed to book tee time")
 )
 
-// GetRandomRetryDelay returns a random delay between min and max seconds with jitter
-func GetRandomRetryDelay(minSeconds, maxSeconds int) time.Duration {
-	// Base delay between min and max
-	baseDelay := minSeconds + rand.Intn(maxSeconds-minSeconds+1)
+// App encapsulates the application dependencies for testability
+type App struct {
+	Config      config.Config
+	Pool        *bookingpool.Pool
+	VoiceClient clients.VoiceService
+	Sinks       []notify.NotificationSink
+	SinkTimeout time.Duration
+	TimeNow     func() time.Time
+	SleepFunc   func(time.Duration)
 
-	// Add jitter of +/- 20% (in milliseconds)
-	jitterRange := float64(baseDelay) * 0.2
-	jitterMs := (rand.Float64() - 0.5) * jitterRange * 1000
+	// Metrics, if set, records booking attempt outcomes, durations, and
+	// retry delays - see main's MetricsAddr wiring. Left nil when metrics
+	// aren't enabled, so every call site nil-checks it rather than
+	// defaulting to a no-op implementation.
+	Metrics *metrics.Metrics
 
-	totalMs := float64(baseDelay)*1000 + jitterMs
-	return time.Duration(totalMs) * time.Millisecond
-}
+	// ClientMetrics, if set, is wired onto every clients.BookingClient
+	// RunCourses constructs, recording the finer-grained per-login/
+	// per-availability-fetch/per-partner-add observations
+	// clients.Metrics exposes - see pkg/clients/metrics and main's
+	// MetricsAddr wiring. a.Pool's own backends get the same treatment in
+	// main via applyClientMetrics, since Pool is built before ClientMetrics
+	// exists.
+	ClientMetrics clients.Metrics
 
-// App encapsulates the application dependencies for testability
-type App struct {
-	Config        config.Config
-	BookingClient clients.BookingService
-	TwilioClient  clients.SMSService
-	TimeNow       func() time.Time
-	SleepFunc     func(time.Duration)
+	// NewBackoff builds the Backoff each booking run uses, fresh per run
+	// since a Backoff carries state between retries (see
+	// bookingfsm.Engine.Run's ctx.backoff().Reset()). Defaults to
+	// bookingfsm.BackoffFromName(conf.BackoffStrategy, conf.BackoffMultiplier)
+	// in NewApp.
+	NewBackoff func() bookingfsm.Backoff
 }
 
-// NewApp creates a new App with real dependencies
-func NewApp(conf config.Config, bookingClient clients.BookingService, twilioClient clients.SMSService) *App {
+// NewApp creates a new App with real dependencies. Each notifier is wrapped
+// in a notify.ClientsNotifierSink and fanned out to on every booking
+// outcome, so a user can run Twilio SMS, ntfy push, or both side by side.
+// voiceClient may be nil, in which case the call fallback in Run is skipped.
+func NewApp(conf config.Config, pool *bookingpool.Pool, voiceClient clients.VoiceService, notifiers ...clients.Notifier) *App {
+	sinks := make([]notify.NotificationSink, len(notifiers))
+	for i, n := range notifiers {
+		sinks[i] = notify.ClientsNotifierSink{Notifier: n, Recipient: conf.ToNumber, DryRun: conf.DryRun}
+	}
+
 	return &App{
-		Config:        conf,
-		BookingClient: bookingClient,
-		TwilioClient:  twilioClient,
-		TimeNow:       time.Now,
-		SleepFunc:     time.Sleep,
+		Config:      conf,
+		Pool:        pool,
+		VoiceClient: voiceClient,
+		Sinks:       sinks,
+		SinkTimeout: conf.NotifySinkTimeout,
+		TimeNow:     time.Now,
+		SleepFunc:   time.Sleep,
+		NewBackoff: func() bookingfsm.Backoff {
+			return bookingfsm.BackoffFromName(conf.BackoffStrategy, conf.BackoffMultiplier)
+		},
 	}
 }
 
-// Run executes the main application logic
-func (a *App) Run() error {
-	ok, err := a.BookingClient.Login(a.Config.Username, a.Config.Pin)
+// notifyAll fans a single Event out to every configured sink, under
+// a.SinkTimeout each - see notify.FanOut. bypassRateLimit should be set for
+// the booking-confirmation event so a successful booking always notifies
+// even if polling alerts have exhausted the recipient's rate limit.
+func (a *App) notifyAll(event notify.Event, bypassRateLimit bool) {
+	ctx := context.Background()
+	if bypassRateLimit {
+		ctx = clients.WithRateLimitBypass(ctx)
+	}
+
+	notify.FanOut(ctx, a.Sinks, event, a.SinkTimeout)
+}
+
+// callIfCloseToTeeOff places a confirmation phone call via a.VoiceClient
+// when the booked tee time falls within Config.NotifyByCallIfWithin of now,
+// on top of the SMS/notifier fan-out, so a last-minute booking isn't missed.
+func (a *App) callIfCloseToTeeOff(dateStr, teeTime string) {
+	if a.VoiceClient == nil || a.Config.NotifyByCallIfWithin <= 0 {
+		return
+	}
+
+	teeOff, err := time.Parse("02-01-2006 15:04", dateStr+" "+teeTime)
 	if err != nil {
-		return fmt.Errorf("login failed: %w", err)
+		slog.Error("failed to parse tee-off time for call fallback", "date", dateStr, "slot_time", teeTime, "error", err.Error())
+		return
 	}
 
-	log.Printf("login status: %t", ok)
+	untilTeeOff := teeOff.Sub(a.TimeNow())
+	if untilTeeOff < 0 || untilTeeOff > a.Config.NotifyByCallIfWithin {
+		return
+	}
 
-	nextBookableDate := a.TimeNow().AddDate(0, 0, a.Config.DaysAhead)
-	dateStr := nextBookableDate.Format("02-01-2006")
+	message := fmt.Sprintf("Your tee time at %s on %s has been booked.", teeTime, dateStr)
+	if err := a.VoiceClient.PlaceCall(a.Config.FromNumber, a.Config.ToNumber, clients.SayTwiml(message), a.Config.DryRun); err != nil {
+		slog.Error("failed to place confirmation call", "date", dateStr, "slot_time", teeTime, "error", err.Error())
+	}
+}
 
-	log.Printf("Finding tee times between %s and %s on date %s. retries %d", a.Config.TimeStart, a.Config.TimeEnd, dateStr, a.Config.Retries)
-	booked := false
+// recordOutcome records a completed booking attempt's outcome and duration
+// to a.Metrics, if set - see metrics.Metrics.BookingAttempt/BookingDuration.
+func (a *App) recordOutcome(outcome string, duration time.Duration) {
+	if a.Metrics == nil {
+		return
+	}
+	a.Metrics.BookingAttempt(outcome)
+	a.Metrics.BookingDuration(outcome, duration)
+}
 
-	for i := 0; i < a.Config.Retries; i++ {
-		availableTimes, err := a.BookingClient.GetCourseAvailability(dateStr)
-		if err != nil {
-			return fmt.Errorf("failed to get availability: %w", err)
-		}
+// recordRetry records an in-place booking retry's backoff delay to
+// a.Metrics (if set) and logs it with enough fields to correlate against a
+// specific course/date/attempt - see bookingfsm.Context.OnRetry.
+func (a *App) recordRetry(course, dateStr string, state bookingfsm.State, attempt int, delay time.Duration) {
+	if a.Metrics != nil {
+		a.Metrics.RetryDelay(delay)
+	}
+	slog.Info("retrying booking step", "course", course, "date", dateStr, "attempt", attempt, "state", state, "delay", delay)
+}
+
+// newBackoff builds a fresh Backoff for one booking run via a.NewBackoff,
+// or bookingfsm's own default (a nil Context.Backoff) when a.NewBackoff
+// is unset - e.g. an App built directly as a struct literal in tests.
+func (a *App) newBackoff() bookingfsm.Backoff {
+	if a.NewBackoff == nil {
+		return nil
+	}
+	return a.NewBackoff()
+}
+
+// scorer builds the teetimes.Scorer used to pick among bookable slots: an
+// equally weighted sum of PreferredTimeScorer, DayOfWeekScorer and
+// CourseIDScorer, fed a.Config's preferred-time/preferred-days/
+// preferred-courses flags via scoringPreferences. For a user who hasn't set
+// any of them every slot scores 0, so the earliest slot is picked, the same
+// outcome as bookingfsm.Context's own nil-Scorer default.
+func (a *App) scorer() teetimes.Scorer {
+	return teetimes.WeightedSumScorer{
+		Scorers: []teetimes.Scorer{
+			teetimes.PreferredTimeScorer{},
+			teetimes.DayOfWeekScorer{},
+			teetimes.CourseIDScorer{},
+		},
+	}
+}
+
+// scoringPreferences builds the teetimes.ScoringContext preference fields
+// from a.Config; bookingfsm.Context fills in DateStr itself once it knows
+// the date being booked.
+func (a *App) scoringPreferences() teetimes.ScoringContext {
+	return teetimes.ScoringContext{
+		PreferredTime:       a.Config.PreferredTime,
+		PreferredDaysOfWeek: a.Config.GetPreferredDaysOfWeekList(),
+		PreferredCourseIDs:  a.Config.GetPreferredCourseIDsList(),
+	}
+}
+
+// notifierConfigFrom adapts conf to clients.NotifierConfig, so both the
+// monolithic tee-sniper binary and the standalone cmd/booker process build
+// their Notifier fan-out through the same shared helper.
+func notifierConfigFrom(conf config.Config) clients.NotifierConfig {
+	return clients.NotifierConfig{
+		Notifier:               conf.Notifier,
+		FromNumber:             conf.FromNumber,
+		NtfyTopicURL:           conf.NtfyTopicURL,
+		VerifyServiceSid:       conf.VerifyServiceSid,
+		VerificationStorePath:  conf.VerificationStorePath,
+		DailyLimitPerRecipient: conf.DailyLimitPerRecipient,
+		RateLimitStorePath:     conf.RateLimitStorePath,
+	}
+}
 
-		availableTimes = teetimes.FilterByBookable(availableTimes)
-		availableTimes = teetimes.SortTimesAscending(availableTimes)
-		availableTimes = teetimes.FilterBetweenTimes(availableTimes, a.Config.TimeStart, a.Config.TimeEnd)
+// sinksFromConfig builds App.Sinks from conf.NotifierURLs via notify.BuildAll,
+// when set. This is the preferred path over notifierConfigFrom's legacy
+// clients.Notifier chain, since a notifier URL can address backends (Slack,
+// Discord, plain webhooks) that have no clients.Notifier equivalent.
+func sinksFromConfig(conf config.Config) ([]notify.NotificationSink, error) {
+	if len(conf.NotifierURLs) == 0 {
+		return nil, nil
+	}
+	return notify.BuildAll(conf.NotifierURLs)
+}
 
-		if len(availableTimes) == 0 {
-			log.Printf("No tee times available between %s and %s on %s. Retrying.", a.Config.TimeStart, a.Config.TimeEnd, dateStr)
-			retryDelay := GetRandomRetryDelay(5, 15)
-			log.Printf("Waiting %v before retry", retryDelay)
-			a.SleepFunc(retryDelay)
-			continue
+// instrumentSinks wraps each of sinks in a notify.InstrumentedSink recording
+// against m, labeling each by the scheme of its corresponding notifier URL
+// when known, falling back to "legacy" for sinks built from the
+// Notifier/FromNumber/ToNumber flags, which have no URL to derive a label
+// from.
+func instrumentSinks(sinks []notify.NotificationSink, notifierURLs []string, m *metrics.Metrics) []notify.NotificationSink {
+	wrapped := make([]notify.NotificationSink, len(sinks))
+	for i, sink := range sinks {
+		backend := "legacy"
+		if i < len(notifierURLs) {
+			if u, err := url.Parse(notifierURLs[i]); err == nil {
+				backend = u.Scheme
+			}
 		}
+		wrapped[i] = notify.InstrumentedSink{Sink: sink, Backend: backend, Metrics: m}
+	}
+	return wrapped
+}
 
-		log.Printf("Found %d available tee times between %s and %s on %s", len(availableTimes), a.Config.TimeStart, a.Config.TimeEnd, dateStr)
+// applyClientMetrics wires m onto every *clients.BookingClient backend in
+// pool, so the per-login/per-availability-fetch/per-partner-add
+// observations clients.Metrics exposes get recorded for the pool-based
+// (non-ConfigFilePath) path too - RunCourses' per-course clients pick up
+// App.ClientMetrics directly since they're built after it exists, but
+// pool is built before MetricsAddr is parsed.
+func applyClientMetrics(pool *bookingpool.Pool, m clients.Metrics) {
+	if pool == nil {
+		return
+	}
+	for _, backend := range pool.Backends {
+		if bc, ok := backend.Client.(*clients.BookingClient); ok {
+			bc.Metrics = m
+		}
+	}
+}
+
+// newBookingClient builds a clients.BookingClient for baseUrl, backing its
+// cookie jar with sessionPath on disk when set so a restart can reuse the
+// session instead of logging in again (see bookingfsm's sessionChecker),
+// and applying conf's HTTP-level retry policy in place of
+// clients.DefaultRetryPolicy.
+func newBookingClient(baseUrl, sessionPath string, conf config.Config) (*clients.BookingClient, error) {
+	var (
+		bookingClient *clients.BookingClient
+		err           error
+	)
+	if sessionPath == "" {
+		bookingClient, err = clients.NewBookingClient(baseUrl)
+	} else {
+		bookingClient, err = clients.NewBookingClientWithSession(baseUrl, sessionPath)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		timeToBook, err := teetimes.PickRandomTime(availableTimes)
+	bookingClient.RetryPolicy = clients.RetryPolicy{
+		BaseDelay:   conf.HTTPRetryBase,
+		MaxDelay:    conf.HTTPRetryMaxDelay,
+		MaxAttempts: conf.HTTPRetryMaxAttempts,
+	}
+	return bookingClient, nil
+}
+
+// poolFromConfig builds the bookingpool.Pool App.Run targets: a multi-course
+// pool loaded from conf.BackendsConfigPath when set, otherwise a single
+// backend built from the legacy Username/Pin/BaseUrl flags.
+func poolFromConfig(conf config.Config) (*bookingpool.Pool, error) {
+	if conf.BackendsConfigPath == "" {
+		bookingClient, err := newBookingClient(conf.BaseUrl, conf.SessionPath, conf)
 		if err != nil {
-			log.Printf("Failed to pick random time: %s", err.Error())
-			continue
+			return nil, err
 		}
-		playingPartners := a.Config.GetPlayingPartnersList()
+		return bookingpool.Single(conf.BaseUrl, conf.Username, conf.Pin, bookingClient), nil
+	}
 
-		log.Printf("Attempting to book tee time: %s on %s for %d people", timeToBook.Time, dateStr, len(playingPartners)+1)
+	backendConfigs, err := bookingpool.LoadBackendConfigs(conf.BackendsConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return bookingpool.NewPoolFromConfigs(backendConfigs)
+}
 
-		bookingID, err := a.BookingClient.BookTimeSlot(timeToBook, playingPartners, a.Config.DryRun)
-		if err != nil {
-			log.Printf("Failed to book time slot: %s", err.Error())
-			retryDelay := GetRandomRetryDelay(3, 8)
-			log.Printf("Waiting %v before retry", retryDelay)
-			a.SleepFunc(retryDelay)
-			continue
-		}
-
-		if bookingID != "" {
-			log.Printf("Successfully booked tee time: %s on %s (booking ID: %s)", timeToBook.Time, dateStr, bookingID)
-
-			for i, partnerID := range playingPartners {
-				slotNumber := i + 2
-				err := a.BookingClient.AddPlayingPartner(bookingID, partnerID, slotNumber, a.Config.DryRun)
-				if err != nil {
-					log.Printf("Failed to add playing partner %s to slot %d: %s", partnerID, slotNumber, err.Error())
-				} else {
-					log.Printf("Added playing partner %s to slot %d", partnerID, slotNumber)
-				}
-			}
+// coursesFromConfig builds App.RunCourses' candidate list: every course in
+// conf.ConfigFilePath when set, otherwise a single course built from the
+// legacy Username/Pin/BaseUrl/TimeStart/TimeEnd flags, so a user who
+// hasn't adopted --config-file yet is unaffected.
+func coursesFromConfig(conf config.Config) ([]config.CourseConfig, error) {
+	if conf.ConfigFilePath == "" {
+		return []config.CourseConfig{{
+			BaseUrl:     conf.BaseUrl,
+			Username:    conf.Username,
+			Pin:         conf.Pin,
+			TimeStart:   conf.TimeStart,
+			TimeEnd:     conf.TimeEnd,
+			SessionPath: conf.SessionPath,
+		}}, nil
+	}
+
+	return config.LoadCourseConfigs(conf.ConfigFilePath)
+}
+
+// courseResult is one course's outcome, reported back to RunCourses over a
+// channel as each racing goroutine finishes.
+type courseResult struct {
+	course config.CourseConfig
+	booked bool
+	err    error
+}
+
+// runEngine drives fsmCtx through engine, resuming from
+// a.Config.CheckpointPath when it's set so a crashed run picks up from its
+// last checkpointed state instead of starting over from
+// bookingfsm.StateInit - see bookingfsm.Engine.Resume.
+func (a *App) runEngine(engine *bookingfsm.Engine, fsmCtx *bookingfsm.Context) (bookingfsm.State, error) {
+	if a.Config.CheckpointPath == "" {
+		return engine.Run(fsmCtx, bookingfsm.StateInit)
+	}
+	return engine.Resume(fsmCtx, a.Config.CheckpointPath)
+}
 
-			message := fmt.Sprintf("Successfully booked tee time: %s on %s for %d people", timeToBook.Time, dateStr, len(playingPartners)+1)
-			err := a.TwilioClient.SendSms(a.Config.FromNumber, a.Config.ToNumber, message, a.Config.DryRun)
-			if err != nil {
-				log.Printf("Failed to send SMS: %s", err.Error())
+// runCourse drives the booking FSM for a single course, bound to ctx so
+// RunCourses can cancel it once a sibling course succeeds.
+func (a *App) runCourse(ctx context.Context, course config.CourseConfig) (bool, error) {
+	bookingClient, err := newBookingClient(course.BaseUrl, course.SessionPath, a.Config)
+	if err != nil {
+		return false, err
+	}
+	if a.ClientMetrics != nil {
+		bookingClient.Metrics = a.ClientMetrics
+	}
+
+	dateStr := a.TimeNow().AddDate(0, 0, a.Config.DaysAhead).Format("02-01-2006")
+	start := a.TimeNow()
+
+	fsmCtx := &bookingfsm.Context{
+		BookingClient:      bookingClient,
+		Ctx:                ctx,
+		Username:           course.Username,
+		Pin:                course.Pin,
+		DaysAhead:          a.Config.DaysAhead,
+		TimeStart:          course.TimeStart,
+		TimeEnd:            course.TimeEnd,
+		DryRun:             a.Config.DryRun,
+		PlayingPartners:    a.Config.GetPlayingPartnersList(),
+		Now:                a.TimeNow,
+		Sleep:              a.SleepFunc,
+		Backoff:            a.newBackoff(),
+		Scorer:             a.scorer(),
+		ScoringPreferences: a.scoringPreferences(),
+		OnRetry: func(state bookingfsm.State, attempt int, delay time.Duration) {
+			a.recordRetry(course.Name, dateStr, state, attempt, delay)
+		},
+		OnBooked: func(dateStr string, slot models.TimeSlot, partners []string) {
+			event := notify.Event{
+				Kind:      notify.EventBooked,
+				Date:      dateStr,
+				SlotTime:  slot.Time,
+				PartySize: len(partners) + 1,
+				Partners:  partners,
 			}
-			log.Println(message)
-			booked = true
-			break
-		} else {
-			log.Printf("Failed to complete booking: %s on %s. Retrying.", timeToBook.Time, dateStr)
-			retryDelay := GetRandomRetryDelay(4, 10)
-			log.Printf("Waiting %v before retry", retryDelay)
-			a.SleepFunc(retryDelay)
+			a.notifyAll(event, a.Config.RateLimitBypass)
+			a.callIfCloseToTeeOff(dateStr, slot.Time)
+			slog.Info("booked tee time", "course", course.Name, "date", dateStr, "slot_time", slot.Time)
+		},
+	}
+
+	engine := bookingfsm.NewEngine(bookingfsm.BookingStates(a.Config.Retries))
+	final, err := a.runEngine(engine, fsmCtx)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			a.recordOutcome("cancelled", a.TimeNow().Sub(start))
+			return false, nil
 		}
+		a.recordOutcome("error", a.TimeNow().Sub(start))
+		return false, err
 	}
 
-	if !booked {
-		message := fmt.Sprintf("Failed to book tee time on %s", dateStr)
-		err := a.TwilioClient.SendSms(a.Config.FromNumber, a.Config.ToNumber, message, a.Config.DryRun)
+	booked := final == bookingfsm.StateDone
+	outcome := "unavailable"
+	if booked {
+		outcome = "booked"
+	}
+	a.recordOutcome(outcome, a.TimeNow().Sub(start))
+
+	return booked, nil
+}
+
+// RunCourses races the booking FSM against every course in courses
+// concurrently, cancelling the rest as soon as one succeeds - in contrast
+// to Run, which tries a.Pool's backends one at a time with failover. It's
+// used instead of Run when --config-file names more than the legacy
+// single-course flags can express.
+func (a *App) RunCourses(ctx context.Context, courses []config.CourseConfig) error {
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan courseResult, len(courses))
+	for _, course := range courses {
+		course := course
+		go func() {
+			booked, err := a.runCourse(raceCtx, course)
+			results <- courseResult{course: course, booked: booked, err: err}
+		}()
+	}
+
+	var anyBooked bool
+	var failures []string
+	for i := 0; i < len(courses); i++ {
+		result := <-results
+		switch {
+		case result.err != nil:
+			slog.Error("course failed", "course", result.course.Name, "error", result.err.Error())
+			failures = append(failures, fmt.Sprintf("%s (error: %s)", result.course.Name, result.err.Error()))
+		case result.booked:
+			anyBooked = true
+			cancel()
+		default:
+			failures = append(failures, fmt.Sprintf("%s (no booking)", result.course.Name))
+		}
+	}
+
+	if anyBooked {
+		return nil
+	}
+
+	dateStr := a.TimeNow().AddDate(0, 0, a.Config.DaysAhead).Format("02-01-2006")
+	detail := fmt.Sprintf("across %d course(s): %s", len(courses), strings.Join(failures, ", "))
+	a.notifyAll(notify.Event{Kind: notify.EventBookingFailed, Date: dateStr, Err: detail}, false)
+	return fmt.Errorf("%w: failed to book tee time on %s %s", ErrNoBooking, dateStr, detail)
+}
+
+// Run health-checks every backend in a.Pool, then drives the booking
+// workflow through pkg/bookingfsm against the best healthy one, falling
+// over to the next candidate on failure instead of sleeping and retrying
+// the same course - see bookingpool.Pool. The outcome is fanned out to
+// a.Sinks (and, on success, a.VoiceClient). See runOnce for a variant that
+// also returns the booking ID, used by the `daemon run` subcommand to
+// record it to pkg/store.
+func (a *App) Run() error {
+	_, err := a.runOnce()
+	return err
+}
+
+// runOnce is Run's implementation, additionally returning the booking ID
+// on success so a caller that needs it (the daemon subcommand's run
+// history) doesn't have to re-derive it from a.Sinks' notified events.
+func (a *App) runOnce() (string, error) {
+	dateStr := a.TimeNow().AddDate(0, 0, a.Config.DaysAhead).Format("02-01-2006")
+	a.Pool.Probe(context.Background(), dateStr)
+
+	for attempt := 0; attempt < len(a.Pool.Backends); attempt++ {
+		backend := a.Pool.Next()
+		if backend == nil {
+			break
+		}
+
+		start := a.TimeNow()
+
+		fsmCtx := &bookingfsm.Context{
+			BookingClient:      backend.Client,
+			Username:           backend.Username,
+			Pin:                backend.Pin,
+			DaysAhead:          a.Config.DaysAhead,
+			TimeStart:          a.Config.TimeStart,
+			TimeEnd:            a.Config.TimeEnd,
+			DryRun:             a.Config.DryRun,
+			PlayingPartners:    a.Config.GetPlayingPartnersList(),
+			Now:                a.TimeNow,
+			Sleep:              a.SleepFunc,
+			Backoff:            a.newBackoff(),
+			Scorer:             a.scorer(),
+			ScoringPreferences: a.scoringPreferences(),
+			OnRetry: func(state bookingfsm.State, retryAttempt int, delay time.Duration) {
+				a.recordRetry(backend.Name, dateStr, state, retryAttempt, delay)
+			},
+			OnBooked: func(dateStr string, slot models.TimeSlot, partners []string) {
+				event := notify.Event{
+					Kind:      notify.EventBooked,
+					Date:      dateStr,
+					SlotTime:  slot.Time,
+					PartySize: len(partners) + 1,
+					Partners:  partners,
+				}
+				a.notifyAll(event, a.Config.RateLimitBypass)
+				a.callIfCloseToTeeOff(dateStr, slot.Time)
+				slog.Info("booked tee time", "course", backend.Name, "date", dateStr, "slot_time", slot.Time)
+			},
+		}
+
+		engine := bookingfsm.NewEngine(bookingfsm.BookingStates(a.Config.Retries))
+		final, err := a.runEngine(engine, fsmCtx)
 		if err != nil {
-			log.Printf("Failed to send SMS: %s", err.Error())
+			a.recordOutcome("error", a.TimeNow().Sub(start))
+			return "", err
+		}
+
+		if final == bookingfsm.StateDone {
+			a.recordOutcome("booked", a.TimeNow().Sub(start))
+			a.Pool.MarkSuccess(backend)
+			return fsmCtx.BookingID, nil
 		}
-		return fmt.Errorf("%w: %s", ErrNoBooking, message)
+
+		a.recordOutcome("unavailable", a.TimeNow().Sub(start))
+		slog.Info("backend failed to book, trying next candidate", "course", backend.Name, "date", dateStr, "attempt", attempt, "state", final)
+		a.Pool.MarkFailure(backend)
 	}
 
-	return nil
+	detail := fmt.Sprintf("across %d backend(s): %s", len(a.Pool.Backends), summarizeStats(a.Pool.Stats()))
+	a.notifyAll(notify.Event{Kind: notify.EventBookingFailed, Date: dateStr, Err: detail}, false)
+	return "", fmt.Errorf("%w: failed to book tee time on %s %s", ErrNoBooking, dateStr, detail)
+}
+
+// summarizeStats renders pool.Stats() for inclusion in the failure
+// notification, so a user can tell which backends were tried and whether
+// they were healthy without digging through logs.
+func summarizeStats(stats []bookingpool.Stats) string {
+	parts := make([]string, len(stats))
+	for i, s := range stats {
+		parts[i] = fmt.Sprintf("%s (healthy=%t, attempts=%d)", s.Name, s.Healthy, s.Attempts)
+	}
+	return strings.Join(parts, ", ")
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		if err := runVerifyCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "daemon" {
+		if err := runDaemonCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	conf, err := config.GetConfig()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	bookingClient, err := clients.NewBookingClient(conf.BaseUrl)
-	if err != nil {
+	logger.Init(conf.LogLevel, conf.LogFormat, conf.LogLabels)
+
+	var pool *bookingpool.Pool
+	if conf.ConfigFilePath == "" {
+		pool, err = poolFromConfig(conf)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	app := NewApp(conf, pool, clients.NewTwilioVoiceClient(), clients.NotifiersFromConfig(notifierConfigFrom(conf))...)
+
+	if sinks, err := sinksFromConfig(conf); err != nil {
 		log.Fatal(err)
+	} else if sinks != nil {
+		app.Sinks = sinks
+	}
+
+	if conf.MetricsAddr != "" {
+		m := metrics.New()
+
+		registry := prometheus.NewRegistry()
+		if err := registry.Register(m); err != nil {
+			log.Fatal(err)
+		}
+
+		app.Sinks = instrumentSinks(app.Sinks, conf.NotifierURLs, m)
+		app.Metrics = m
+
+		cm := clientsmetrics.New("tee_sniper")
+		if err := registry.Register(cm); err != nil {
+			log.Fatal(err)
+		}
+		app.ClientMetrics = cm
+		applyClientMetrics(pool, cm)
+
+		server := metrics.NewServer(conf.MetricsAddr, registry)
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("metrics server stopped: %s", err.Error())
+			}
+		}()
 	}
 
-	twilioClient := clients.NewTwilioClient()
+	if conf.ConfigFilePath != "" {
+		courses, err := coursesFromConfig(conf)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := app.RunCourses(context.Background(), courses); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	app := NewApp(conf, bookingClient, twilioClient)
 	if err := app.Run(); err != nil {
 		log.Fatal(err)
 	}
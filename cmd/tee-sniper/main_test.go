@@ -1,109 +1,103 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
-	"github.com/golang/mock/gomock"
-	"github.com/stebennett/tee-sniper/pkg/clients/mocks"
+	"github.com/stebennett/tee-sniper/pkg/bookingpool"
+	"github.com/stebennett/tee-sniper/pkg/clients"
 	"github.com/stebennett/tee-sniper/pkg/config"
 	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stebennett/tee-sniper/pkg/notify"
 	"github.com/stretchr/testify/assert"
 )
 
-// =============================================================================
-// GetRandomRetryDelay Tests
-// =============================================================================
+// fakeBookingService is a test double for clients.BookingService.
+type fakeBookingService struct {
+	loginErr error
 
-func TestGetRandomRetryDelayWithinRange(t *testing.T) {
-	minSeconds := 5
-	maxSeconds := 15
+	availability    []models.TimeSlot
+	availabilityErr error
+	gotDateStr      string
 
-	for i := 0; i < 100; i++ {
-		delay := GetRandomRetryDelay(minSeconds, maxSeconds)
-
-		minExpected := time.Duration(float64(minSeconds)*0.8*1000) * time.Millisecond
-		maxExpected := time.Duration(float64(maxSeconds)*1.2*1000) * time.Millisecond
-
-		assert.GreaterOrEqual(t, delay, minExpected, "delay should be >= min with jitter")
-		assert.LessOrEqual(t, delay, maxExpected, "delay should be <= max with jitter")
-	}
+	bookingID  string
+	bookErr    error
+	partnerErr map[string]error
 }
 
-func TestGetRandomRetryDelayMinEqualsMax(t *testing.T) {
-	seconds := 10
-
-	for i := 0; i < 50; i++ {
-		delay := GetRandomRetryDelay(seconds, seconds)
+func (f *fakeBookingService) Login(username, pin string) (bool, error) {
+	return f.loginErr == nil, f.loginErr
+}
 
-		minExpected := time.Duration(float64(seconds)*0.8*1000) * time.Millisecond
-		maxExpected := time.Duration(float64(seconds)*1.2*1000) * time.Millisecond
+func (f *fakeBookingService) LoginContext(ctx context.Context, username, pin string) (bool, error) {
+	return f.Login(username, pin)
+}
 
-		assert.GreaterOrEqual(t, delay, minExpected)
-		assert.LessOrEqual(t, delay, maxExpected)
-	}
+func (f *fakeBookingService) GetCourseAvailability(dateStr string) ([]models.TimeSlot, error) {
+	f.gotDateStr = dateStr
+	return f.availability, f.availabilityErr
 }
 
-func TestGetRandomRetryDelayReturnsPositive(t *testing.T) {
-	delay := GetRandomRetryDelay(1, 5)
-	assert.Greater(t, delay, time.Duration(0))
+func (f *fakeBookingService) GetCourseAvailabilityContext(ctx context.Context, dateStr string) ([]models.TimeSlot, error) {
+	return f.GetCourseAvailability(dateStr)
 }
 
-func TestGetRandomRetryDelayHasVariation(t *testing.T) {
-	delays := make(map[time.Duration]bool)
+func (f *fakeBookingService) BookTimeSlot(slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.bookingID, f.bookErr
+}
 
-	for i := 0; i < 100; i++ {
-		delay := GetRandomRetryDelay(5, 15)
-		delays[delay] = true
-	}
+func (f *fakeBookingService) BookTimeSlotContext(ctx context.Context, slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.BookTimeSlot(slot, partners, dryRun)
+}
 
-	assert.Greater(t, len(delays), 1, "delays should have variation due to randomness")
+func (f *fakeBookingService) AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.partnerErr[partnerID]
 }
 
-// =============================================================================
-// NewApp Tests
-// =============================================================================
+func (f *fakeBookingService) AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.AddPlayingPartner(bookingID, partnerID, slotNumber, dryRun)
+}
 
-func TestNewApp(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockBooking := mocks.NewMockBookingService(ctrl)
-	mockSMS := mocks.NewMockSMSService(ctrl)
-	conf := config.Config{
-		Username: "testuser",
-		Pin:      "1234",
-	}
+// fakeNotifier is a test double for clients.Notifier.
+type fakeNotifier struct {
+	calls []notifyCall
+	err   error
+}
 
-	app := NewApp(conf, mockBooking, mockSMS)
+type notifyCall struct {
+	recipient, subject, body string
+	dryRun                   bool
+}
 
-	assert.NotNil(t, app)
-	assert.Equal(t, conf, app.Config)
-	assert.NotNil(t, app.BookingClient)
-	assert.NotNil(t, app.TwilioClient)
-	assert.NotNil(t, app.TimeNow)
-	assert.NotNil(t, app.SleepFunc)
+func (f *fakeNotifier) Notify(ctx context.Context, recipient, subject, body string, priority clients.Priority, dryRun bool) error {
+	f.calls = append(f.calls, notifyCall{recipient, subject, body, dryRun})
+	return f.err
 }
 
-// =============================================================================
-// App.Run() Tests
-// =============================================================================
+// mockSink is a test double for notify.NotificationSink that records the
+// typed Event it was sent, so tests assert on structured fields rather than
+// a rendered message string.
+type mockSink struct {
+	calls []notify.Event
+	err   error
+}
 
-func createTestApp(t *testing.T, conf config.Config) (*App, *mocks.MockBookingService, *mocks.MockSMSService, *gomock.Controller) {
-	ctrl := gomock.NewController(t)
-	mockBooking := mocks.NewMockBookingService(ctrl)
-	mockSMS := mocks.NewMockSMSService(ctrl)
+func (s *mockSink) Notify(ctx context.Context, event notify.Event) error {
+	s.calls = append(s.calls, event)
+	return s.err
+}
 
-	app := &App{
-		Config:        conf,
-		BookingClient: mockBooking,
-		TwilioClient:  mockSMS,
-		TimeNow:       func() time.Time { return time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC) },
-		SleepFunc:     func(d time.Duration) {},
-	}
+// fakeVoiceClient is a test double for clients.VoiceService.
+type fakeVoiceClient struct {
+	called bool
+	err    error
+}
 
-	return app, mockBooking, mockSMS, ctrl
+func (f *fakeVoiceClient) PlaceCall(from, to, twimlOrURL string, dryRun bool) error {
+	f.called = true
+	return f.err
 }
 
 func defaultTestConfig() config.Config {
@@ -121,345 +115,194 @@ func defaultTestConfig() config.Config {
 	}
 }
 
-func TestRunLoginError(t *testing.T) {
-	app, mockBooking, _, ctrl := createTestApp(t, defaultTestConfig())
-	defer ctrl.Finish()
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(false, errors.New("invalid credentials"))
+func createTestApp(t *testing.T, conf config.Config) (*App, *fakeBookingService, *mockSink) {
+	booking := &fakeBookingService{}
+	sink := &mockSink{}
 
-	err := app.Run()
+	app := &App{
+		Config:      conf,
+		Pool:        bookingpool.Single("test-course", conf.Username, conf.Pin, booking),
+		Sinks:       []notify.NotificationSink{sink},
+		SinkTimeout: time.Second,
+		TimeNow:     func() time.Time { return time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC) },
+		SleepFunc:   func(d time.Duration) {},
+	}
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "login failed")
+	return app, booking, sink
 }
 
-func TestRunGetAvailabilityError(t *testing.T) {
-	app, mockBooking, _, ctrl := createTestApp(t, defaultTestConfig())
-	defer ctrl.Finish()
+func TestNewApp(t *testing.T) {
+	booking := &fakeBookingService{}
+	notifier := &fakeNotifier{}
+	conf := config.Config{Username: "testuser", Pin: "1234"}
+	pool := bookingpool.Single("test-course", conf.Username, conf.Pin, booking)
+
+	app := NewApp(conf, pool, nil, notifier)
+
+	assert.NotNil(t, app)
+	assert.Equal(t, conf, app.Config)
+	assert.NotNil(t, app.Pool)
+	assert.Len(t, app.Sinks, 1)
+	assert.NotNil(t, app.TimeNow)
+	assert.NotNil(t, app.SleepFunc)
+}
 
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(nil, errors.New("network error"))
+func TestRunLoginErrorIsReportedAsFailure(t *testing.T) {
+	app, booking, sink := createTestApp(t, defaultTestConfig())
+	booking.loginErr = errors.New("invalid credentials")
 
 	err := app.Run()
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to get availability")
+	assert.True(t, errors.Is(err, ErrNoBooking))
+	assert.Len(t, sink.calls, 1)
+	assert.Equal(t, notify.EventBookingFailed, sink.calls[0].Kind)
 }
 
-func TestRunSuccessfulBookingFirstAttempt(t *testing.T) {
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, defaultTestConfig())
-	defer ctrl.Finish()
-
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil)
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), gomock.Any(), false).
-		Return("booking-123", nil)
-	mockSMS.EXPECT().
-		SendSms("+1234567890", "+0987654321", gomock.Any(), false).
-		Return(nil)
+func TestRunSuccessfulBooking(t *testing.T) {
+	app, booking, sink := createTestApp(t, defaultTestConfig())
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
 
 	err := app.Run()
 
 	assert.NoError(t, err)
+	assert.Len(t, sink.calls, 1)
+	assert.Equal(t, notify.EventBooked, sink.calls[0].Kind)
+	assert.Equal(t, "10:00", sink.calls[0].SlotTime)
 }
 
 func TestRunSuccessfulBookingWithPartners(t *testing.T) {
 	conf := defaultTestConfig()
 	conf.PlayingPartners = "partner1,partner2"
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil)
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), []string{"partner1", "partner2"}, false).
-		Return("booking-123", nil)
-	mockBooking.EXPECT().
-		AddPlayingPartner("booking-123", "partner1", 2, false).
-		Return(nil)
-	mockBooking.EXPECT().
-		AddPlayingPartner("booking-123", "partner2", 3, false).
-		Return(nil)
-	mockSMS.EXPECT().
-		SendSms("+1234567890", "+0987654321", gomock.Any(), false).
-		Return(nil)
+	app, booking, sink := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
 
 	err := app.Run()
 
 	assert.NoError(t, err)
+	assert.Len(t, sink.calls, 1)
 }
 
-func TestRunPartnerAddFailureContinues(t *testing.T) {
+func TestRunPartnerAddFailureContinuesWhenSomePartnersAdded(t *testing.T) {
 	conf := defaultTestConfig()
 	conf.PlayingPartners = "partner1,partner2"
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil)
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), []string{"partner1", "partner2"}, false).
-		Return("booking-123", nil)
-	mockBooking.EXPECT().
-		AddPlayingPartner("booking-123", "partner1", 2, false).
-		Return(errors.New("partner not found"))
-	mockBooking.EXPECT().
-		AddPlayingPartner("booking-123", "partner2", 3, false).
-		Return(nil)
-	mockSMS.EXPECT().
-		SendSms("+1234567890", "+0987654321", gomock.Any(), false).
-		Return(nil)
+	app, booking, sink := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
+	booking.partnerErr = map[string]error{"partner1": errors.New("partner not found")}
 
 	err := app.Run()
 
 	assert.NoError(t, err)
+	assert.Len(t, sink.calls, 1)
 }
 
-func TestRunRetryOnNoAvailability(t *testing.T) {
+func TestRunAllPartnersFailingToAddFailsTheBooking(t *testing.T) {
 	conf := defaultTestConfig()
-	conf.Retries = 2
-
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	emptySlots := []models.TimeSlot{}
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
+	conf.PlayingPartners = "partner1"
 
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-
-	gomock.InOrder(
-		mockBooking.EXPECT().
-			GetCourseAvailability("22-01-2024").
-			Return(emptySlots, nil),
-		mockBooking.EXPECT().
-			GetCourseAvailability("22-01-2024").
-			Return(availableSlots, nil),
-	)
-
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), gomock.Any(), false).
-		Return("booking-123", nil)
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
+	app, booking, sink := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
+	booking.partnerErr = map[string]error{"partner1": errors.New("partner not found")}
 
 	err := app.Run()
 
-	assert.NoError(t, err)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNoBooking))
+	assert.Len(t, sink.calls, 1)
+	assert.Equal(t, notify.EventBookingFailed, sink.calls[0].Kind)
 }
 
-func TestRunRetryOnBookingFailure(t *testing.T) {
+func TestRunAllRetriesExhaustedOnNoAvailability(t *testing.T) {
 	conf := defaultTestConfig()
-	conf.Retries = 2
-
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
+	conf.Retries = 0
 
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil).
-		Times(2)
-
-	gomock.InOrder(
-		mockBooking.EXPECT().
-			BookTimeSlot(gomock.Any(), gomock.Any(), false).
-			Return("", errors.New("slot taken")),
-		mockBooking.EXPECT().
-			BookTimeSlot(gomock.Any(), gomock.Any(), false).
-			Return("booking-123", nil),
-	)
-
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
+	app, booking, sink := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{}
 
 	err := app.Run()
 
-	assert.NoError(t, err)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNoBooking))
+	assert.Equal(t, notify.EventBookingFailed, sink.calls[0].Kind)
 }
 
-func TestRunRetryOnEmptyBookingID(t *testing.T) {
-	conf := defaultTestConfig()
-	conf.Retries = 2
-
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil).
-		Times(2)
-
-	gomock.InOrder(
-		mockBooking.EXPECT().
-			BookTimeSlot(gomock.Any(), gomock.Any(), false).
-			Return("", nil),
-		mockBooking.EXPECT().
-			BookTimeSlot(gomock.Any(), gomock.Any(), false).
-			Return("booking-123", nil),
-	)
-
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
+func TestRunSMSErrorDoesNotFailBooking(t *testing.T) {
+	app, booking, sink := createTestApp(t, defaultTestConfig())
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
+	sink.err = errors.New("SMS failed")
 
 	err := app.Run()
 
 	assert.NoError(t, err)
 }
 
-func TestRunAllRetriesExhausted(t *testing.T) {
+func TestRunDryRunMode(t *testing.T) {
 	conf := defaultTestConfig()
-	conf.Retries = 2
-
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	emptySlots := []models.TimeSlot{}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(emptySlots, nil).
-		Times(2)
+	conf.DryRun = true
 
-	mockSMS.EXPECT().
-		SendSms("+1234567890", "+0987654321", gomock.Any(), false).
-		Return(nil)
+	app, booking, sink := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "dry-run-123"
 
 	err := app.Run()
 
-	assert.Error(t, err)
-	assert.True(t, errors.Is(err, ErrNoBooking))
+	assert.NoError(t, err)
+	assert.Len(t, sink.calls, 1)
 }
 
-func TestRunSendsFailureSMS(t *testing.T) {
+func TestRunPlacesCallWhenCloseToTeeOff(t *testing.T) {
 	conf := defaultTestConfig()
-	conf.Retries = 1
+	conf.DaysAhead = 0
+	conf.NotifyByCallIfWithin = 2 * time.Hour
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
+	app, booking, _ := createTestApp(t, conf)
+	app.TimeNow = func() time.Time { return time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC) }
+	voice := &fakeVoiceClient{}
+	app.VoiceClient = voice
 
-	emptySlots := []models.TimeSlot{}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(emptySlots, nil)
-
-	mockSMS.EXPECT().
-		SendSms("+1234567890", "+0987654321", "Failed to book tee time on 22-01-2024", false).
-		Return(nil)
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
 
 	err := app.Run()
 
-	assert.Error(t, err)
+	assert.NoError(t, err)
+	assert.True(t, voice.called)
 }
 
-func TestRunSMSErrorDoesNotFailBooking(t *testing.T) {
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, defaultTestConfig())
-	defer ctrl.Finish()
+func TestRunSkipsCallWhenOutsideThreshold(t *testing.T) {
+	conf := defaultTestConfig()
+	conf.NotifyByCallIfWithin = 2 * time.Hour
 
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
+	app, booking, _ := createTestApp(t, conf)
+	voice := &fakeVoiceClient{}
+	app.VoiceClient = voice
 
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil)
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), gomock.Any(), false).
-		Return("booking-123", nil)
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(errors.New("SMS failed"))
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
 
 	err := app.Run()
 
 	assert.NoError(t, err)
+	assert.False(t, voice.called)
 }
 
-func TestRunDryRunMode(t *testing.T) {
+func TestRunSkipsCallWhenVoiceClientNil(t *testing.T) {
 	conf := defaultTestConfig()
-	conf.DryRun = true
+	conf.DaysAhead = 0
+	conf.NotifyByCallIfWithin = 2 * time.Hour
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
+	app, booking, _ := createTestApp(t, conf)
+	app.TimeNow = func() time.Time { return time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC) }
 
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(availableSlots, nil)
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), gomock.Any(), true).
-		Return("dry-run-123", nil)
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), true).
-		Return(nil)
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
 
 	err := app.Run()
 
@@ -470,25 +313,12 @@ func TestRunFiltersNonBookableSlots(t *testing.T) {
 	conf := defaultTestConfig()
 	conf.Retries = 1
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	slotsWithNonBookable := []models.TimeSlot{
-		{Time: "10:00", CanBook: false, BookingForm: map[string]string{"id": "1"}},
-		{Time: "11:00", CanBook: false, BookingForm: map[string]string{"id": "2"}},
+	app, booking, _ := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{
+		{Time: "10:00", CanBook: false},
+		{Time: "11:00", CanBook: false},
 	}
 
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(slotsWithNonBookable, nil)
-
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
-
 	err := app.Run()
 
 	assert.Error(t, err)
@@ -501,25 +331,12 @@ func TestRunFiltersOutsideTimeRange(t *testing.T) {
 	conf.TimeEnd = "16:00"
 	conf.Retries = 1
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	slotsOutsideRange := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-		{Time: "11:00", CanBook: true, BookingForm: map[string]string{"id": "2"}},
+	app, booking, _ := createTestApp(t, conf)
+	booking.availability = []models.TimeSlot{
+		{Time: "10:00", CanBook: true},
+		{Time: "11:00", CanBook: true},
 	}
 
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("22-01-2024").
-		Return(slotsOutsideRange, nil)
-
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
-
 	err := app.Run()
 
 	assert.Error(t, err)
@@ -530,84 +347,13 @@ func TestRunUsesCorrectDateFormat(t *testing.T) {
 	conf := defaultTestConfig()
 	conf.DaysAhead = 10
 
-	app, mockBooking, mockSMS, ctrl := createTestApp(t, conf)
-	defer ctrl.Finish()
-
-	app.TimeNow = func() time.Time {
-		return time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
-	}
-
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-	mockBooking.EXPECT().
-		GetCourseAvailability("15-03-2024").
-		Return(availableSlots, nil)
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), gomock.Any(), false).
-		Return("booking-123", nil)
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
-
-	err := app.Run()
-
-	assert.NoError(t, err)
-}
-
-func TestRunSleepCalledOnRetry(t *testing.T) {
-	conf := defaultTestConfig()
-	conf.Retries = 2
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockBooking := mocks.NewMockBookingService(ctrl)
-	mockSMS := mocks.NewMockSMSService(ctrl)
-
-	sleepCalled := false
-	app := &App{
-		Config:        conf,
-		BookingClient: mockBooking,
-		TwilioClient:  mockSMS,
-		TimeNow:       func() time.Time { return time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC) },
-		SleepFunc: func(d time.Duration) {
-			sleepCalled = true
-			assert.Greater(t, d, time.Duration(0))
-		},
-	}
-
-	emptySlots := []models.TimeSlot{}
-	availableSlots := []models.TimeSlot{
-		{Time: "10:00", CanBook: true, BookingForm: map[string]string{"id": "1"}},
-	}
-
-	mockBooking.EXPECT().
-		Login("testuser", "1234").
-		Return(true, nil)
-
-	gomock.InOrder(
-		mockBooking.EXPECT().
-			GetCourseAvailability("22-01-2024").
-			Return(emptySlots, nil),
-		mockBooking.EXPECT().
-			GetCourseAvailability("22-01-2024").
-			Return(availableSlots, nil),
-	)
-
-	mockBooking.EXPECT().
-		BookTimeSlot(gomock.Any(), gomock.Any(), false).
-		Return("booking-123", nil)
-	mockSMS.EXPECT().
-		SendSms(gomock.Any(), gomock.Any(), gomock.Any(), false).
-		Return(nil)
+	app, booking, _ := createTestApp(t, conf)
+	app.TimeNow = func() time.Time { return time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC) }
+	booking.availability = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+	booking.bookingID = "booking-123"
 
 	err := app.Run()
 
 	assert.NoError(t, err)
-	assert.True(t, sleepCalled, "SleepFunc should be called on retry")
+	assert.Equal(t, "15-03-2024", booking.gotDateStr)
 }
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/teetimes"
+)
+
+// ClientFactory builds the clients.BookingService used to drive a Snipe's
+// CourseURL. Runner caches one client per CourseURL (see Runner.clientFor),
+// so multiple Snipes targeting the same course share one client; each
+// attempt still logs in through that client before fetching availability.
+type ClientFactory func(courseURL string) (clients.BookingService, error)
+
+// Runner polls Snipes on an interval and drives each one through the
+// booking path, recording every successful booking to Bookings and
+// deleting the fulfilled Snipe - this is what actually executes a snipe
+// scheduled via POST /v1/snipes; without it, Create/List/Delete are pure
+// bookkeeping that nothing ever acts on.
+type Runner struct {
+	Snipes   *SnipeStore
+	Bookings *BookingStore
+	Client   ClientFactory
+	Username string
+	Pin      string
+
+	// PollInterval is how often Run checks Snipes for a bookable slot.
+	// Defaults to time.Minute.
+	PollInterval time.Duration
+
+	// Now defaults to time.Now; overridden in tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]clients.BookingService
+}
+
+// Run polls every PollInterval, attempting each stored Snipe, until ctx is
+// cancelled. It fires once immediately on entry rather than waiting out
+// the first interval.
+func (r *Runner) Run(ctx context.Context) error {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick attempts every currently stored Snipe once, logging (rather than
+// aborting the loop on) a single Snipe's failure so one broken course
+// doesn't stop the others from being attempted.
+func (r *Runner) tick(ctx context.Context) {
+	for _, snipe := range r.Snipes.List() {
+		booked, err := r.attempt(ctx, snipe)
+		if err != nil {
+			log.Printf("api: snipe %s attempt failed: %s", snipe.ID, err.Error())
+			continue
+		}
+		if !booked {
+			continue
+		}
+		if _, err := r.Snipes.Delete(snipe.ID); err != nil {
+			log.Printf("api: snipe %s booked but failed to remove from store: %s", snipe.ID, err.Error())
+		}
+	}
+}
+
+// attempt logs into snipe's CourseURL, looks for a bookable slot on
+// snipe.Date strictly between snipe.Earliest and snipe.Latest (both ends
+// excluded, as teetimes.FilterBetweenTimes does), and books the earliest
+// match. It reports booked=false (not an error) when no matching slot is
+// available yet, so the caller retries on the next tick.
+func (r *Runner) attempt(ctx context.Context, snipe Snipe) (booked bool, err error) {
+	client, err := r.clientFor(snipe.CourseURL)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := client.LoginContext(ctx, r.Username, r.Pin); err != nil {
+		return false, err
+	}
+
+	slots, err := client.GetCourseAvailabilityContext(ctx, snipe.Date)
+	if err != nil {
+		return false, err
+	}
+
+	slots = teetimes.FilterByBookable(slots)
+	slots = teetimes.SortTimesAscending(slots)
+	slots = teetimes.FilterBetweenTimes(slots, snipe.Earliest, snipe.Latest)
+	if len(slots) == 0 {
+		return false, nil
+	}
+
+	bookingID, err := client.BookTimeSlotContext(ctx, slots[0], snipe.Partners, false)
+	if err != nil {
+		return false, err
+	}
+	if bookingID == "" {
+		return false, nil
+	}
+
+	if err := r.Bookings.Record(Booking{
+		ID:        bookingID,
+		CourseURL: snipe.CourseURL,
+		Date:      snipe.Date,
+		Time:      slots[0].Time,
+		BookedAt:  r.now(),
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// clientFor returns the cached client for courseURL, building and caching
+// one via r.Client on first use.
+func (r *Runner) clientFor(courseURL string) (clients.BookingService, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.clients == nil {
+		r.clients = make(map[string]clients.BookingService)
+	}
+	if c, ok := r.clients[courseURL]; ok {
+		return c, nil
+	}
+
+	c, err := r.Client(courseURL)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[courseURL] = c
+	return c, nil
+}
+
+func (r *Runner) now() time.Time {
+	if r.Now == nil {
+		return time.Now()
+	}
+	return r.Now()
+}
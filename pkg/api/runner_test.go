@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBookingService is a test double for clients.BookingService, mirroring
+// bookingfsm's fake of the same name.
+type fakeBookingService struct {
+	availability []models.TimeSlot
+	bookingID    string
+	bookErr      error
+}
+
+func (f *fakeBookingService) Login(username, pin string) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeBookingService) LoginContext(ctx context.Context, username, pin string) (bool, error) {
+	return f.Login(username, pin)
+}
+
+func (f *fakeBookingService) GetCourseAvailability(dateStr string) ([]models.TimeSlot, error) {
+	return f.availability, nil
+}
+
+func (f *fakeBookingService) GetCourseAvailabilityContext(ctx context.Context, dateStr string) ([]models.TimeSlot, error) {
+	return f.GetCourseAvailability(dateStr)
+}
+
+func (f *fakeBookingService) BookTimeSlot(slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.bookingID, f.bookErr
+}
+
+func (f *fakeBookingService) BookTimeSlotContext(ctx context.Context, slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.BookTimeSlot(slot, partners, dryRun)
+}
+
+func (f *fakeBookingService) AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return nil
+}
+
+func (f *fakeBookingService) AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.AddPlayingPartner(bookingID, partnerID, slotNumber, dryRun)
+}
+
+func newTestRunner(t *testing.T, client clients.BookingService) *Runner {
+	t.Helper()
+
+	snipes, err := NewSnipeStore(filepath.Join(t.TempDir(), "snipes.json"))
+	require.NoError(t, err)
+	bookings, err := NewBookingStore(filepath.Join(t.TempDir(), "bookings.json"))
+	require.NoError(t, err)
+
+	return &Runner{
+		Snipes:   snipes,
+		Bookings: bookings,
+		Client:   func(string) (clients.BookingService, error) { return client, nil },
+		Now:      func() time.Time { return time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestRunnerAttemptBooksMatchingSlotAndRecordsIt(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+	}
+	r := newTestRunner(t, client)
+
+	snipe, err := r.Snipes.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025", Earliest: "09:00", Latest: "17:00"})
+	require.NoError(t, err)
+
+	r.tick(context.Background())
+
+	assert.Empty(t, r.Snipes.List())
+	bookings := r.Bookings.List()
+	require.Len(t, bookings, 1)
+	assert.Equal(t, "booking-123", bookings[0].ID)
+	assert.Equal(t, "10:00", bookings[0].Time)
+	assert.Equal(t, snipe.CourseURL, bookings[0].CourseURL)
+}
+
+func TestRunnerAttemptLeavesSnipeWhenNoSlotMatches(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "08:00", CanBook: true}},
+	}
+	r := newTestRunner(t, client)
+
+	_, err := r.Snipes.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025", Earliest: "09:00", Latest: "17:00"})
+	require.NoError(t, err)
+
+	r.tick(context.Background())
+
+	assert.Len(t, r.Snipes.List(), 1)
+	assert.Empty(t, r.Bookings.List())
+}
+
+func TestRunnerAttemptLeavesSnipeOnBookingError(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookErr:      assert.AnError,
+	}
+	r := newTestRunner(t, client)
+
+	_, err := r.Snipes.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025", Earliest: "09:00", Latest: "17:00"})
+	require.NoError(t, err)
+
+	r.tick(context.Background())
+
+	assert.Len(t, r.Snipes.List(), 1)
+	assert.Empty(t, r.Bookings.List())
+}
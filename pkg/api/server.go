@@ -0,0 +1,153 @@
+// Package api exposes an HTTP control plane for scheduling snipes and
+// reviewing past bookings, so tee-sniper can run as a long-lived service
+// that multiple household members or a small club drive from their
+// phones instead of a single cron-triggered one-shot.
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrUnauthorized is written as the body of a 401 response when a
+// mutating request's bearer token doesn't match Server's configured
+// Token.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Server exposes the snipe-scheduling HTTP API:
+//
+//	POST   /v1/snipes      schedule a new snipe
+//	GET    /v1/snipes      list scheduled snipes
+//	DELETE /v1/snipes/{id} cancel a scheduled snipe
+//	GET    /v1/bookings    list past booking confirmations
+//
+// The mutating routes (POST, DELETE) require a bearer token matching
+// Token; the read-only routes do not, so household members can check
+// status from their phones without the signing key.
+type Server struct {
+	Snipes   *SnipeStore
+	Bookings *BookingStore
+	Token    string
+}
+
+// NewServer creates a Server backed by the given stores and bearer token.
+func NewServer(snipes *SnipeStore, bookings *BookingStore, token string) *Server {
+	return &Server{Snipes: snipes, Bookings: bookings, Token: token}
+}
+
+// Handler returns the http.Handler serving the API's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/snipes", s.handleSnipesCollection)
+	mux.HandleFunc("/v1/snipes/", s.requireAuth(s.handleSnipeItem))
+	mux.HandleFunc("/v1/bookings", s.handleListBookings)
+
+	return mux
+}
+
+// requireAuth wraps next so it is only called once the request carries an
+// `Authorization: Bearer <Token>` header matching s.Token.
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			http.Error(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleSnipesCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleListSnipes(w, r)
+	case http.MethodPost:
+		s.requireAuth(s.handleCreateSnipe)(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleListSnipes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Snipes.List())
+}
+
+// createSnipeRequest is the POST /v1/snipes request body.
+type createSnipeRequest struct {
+	CourseURL string   `json:"course_url"`
+	Date      string   `json:"date"`
+	Earliest  string   `json:"earliest"`
+	Latest    string   `json:"latest"`
+	Partners  []string `json:"partners"`
+	Notify    bool     `json:"notify"`
+}
+
+func (s *Server) handleCreateSnipe(w http.ResponseWriter, r *http.Request) {
+	var req createSnipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	snipe, err := s.Snipes.Create(Snipe{
+		CourseURL: req.CourseURL,
+		Date:      req.Date,
+		Earliest:  req.Earliest,
+		Latest:    req.Latest,
+		Partners:  req.Partners,
+		Notify:    req.Notify,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, snipe)
+}
+
+// handleSnipeItem serves DELETE /v1/snipes/{id}.
+func (s *Server) handleSnipeItem(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/v1/snipes/")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	found, err := s.Snipes.Delete(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListBookings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.Bookings.List())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	snipes, err := NewSnipeStore(filepath.Join(t.TempDir(), "snipes.json"))
+	require.NoError(t, err)
+	bookings, err := NewBookingStore(filepath.Join(t.TempDir(), "bookings.json"))
+	require.NoError(t, err)
+	return NewServer(snipes, bookings, "secret-token")
+}
+
+// TestCreateSnipeRequiresAuth tests that POST /v1/snipes is rejected
+// without a matching bearer token.
+func TestCreateSnipeRequiresAuth(t *testing.T) {
+	server := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/snipes", bytes.NewBufferString(`{"course_url":"https://example.com"}`))
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestCreateSnipeWithValidTokenSucceeds tests that a correctly
+// authenticated POST /v1/snipes creates and returns a Snipe.
+func TestCreateSnipeWithValidTokenSucceeds(t *testing.T) {
+	server := newTestServer(t)
+
+	body := `{"course_url":"https://example.com","date":"15-01-2025","earliest":"08:00","latest":"10:00","partners":["p1"],"notify":true}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/snipes", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var snipe Snipe
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&snipe))
+	assert.NotEmpty(t, snipe.ID)
+	assert.Equal(t, "https://example.com", snipe.CourseURL)
+	assert.Equal(t, []string{"p1"}, snipe.Partners)
+}
+
+// TestCreateSnipeWithWrongTokenFails tests that an incorrect bearer token
+// is rejected.
+func TestCreateSnipeWithWrongTokenFails(t *testing.T) {
+	server := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/snipes", bytes.NewBufferString(`{}`))
+	req.Header.Set("Authorization", "Bearer wrong-token")
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestListSnipesDoesNotRequireAuth tests that GET /v1/snipes is readable
+// without a bearer token.
+func TestListSnipesDoesNotRequireAuth(t *testing.T) {
+	server := newTestServer(t)
+	_, err := server.Snipes.Create(Snipe{CourseURL: "https://example.com"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/snipes", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var snipes []Snipe
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&snipes))
+	assert.Len(t, snipes, 1)
+}
+
+// TestDeleteSnipeRequiresAuth tests that DELETE /v1/snipes/{id} is
+// rejected without a matching bearer token.
+func TestDeleteSnipeRequiresAuth(t *testing.T) {
+	server := newTestServer(t)
+	created, err := server.Snipes.Create(Snipe{CourseURL: "https://example.com"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/snipes/"+created.ID, nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Len(t, server.Snipes.List(), 1)
+}
+
+// TestDeleteSnipeWithValidTokenRemovesIt tests that an authenticated
+// DELETE removes the Snipe.
+func TestDeleteSnipeWithValidTokenRemovesIt(t *testing.T) {
+	server := newTestServer(t)
+	created, err := server.Snipes.Create(Snipe{CourseURL: "https://example.com"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/snipes/"+created.ID, nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, server.Snipes.List())
+}
+
+// TestDeleteUnknownSnipeReturnsNotFound tests that deleting an unknown ID
+// returns 404.
+func TestDeleteUnknownSnipeReturnsNotFound(t *testing.T) {
+	server := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/snipes/does-not-exist", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// TestListBookingsDoesNotRequireAuth tests that GET /v1/bookings is
+// readable without a bearer token and returns recorded bookings.
+func TestListBookingsDoesNotRequireAuth(t *testing.T) {
+	server := newTestServer(t)
+	require.NoError(t, server.Bookings.Record(Booking{ID: "abc123", Time: "09:00"}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/bookings", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var bookings []Booking
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&bookings))
+	require.Len(t, bookings, 1)
+	assert.Equal(t, "abc123", bookings[0].ID)
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// newID generates a short random identifier for a Snipe or Booking.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SnipeStore persists the set of scheduled Snipes to disk, so a restart
+// of the API server doesn't lose what household members have scheduled.
+type SnipeStore struct {
+	mu     sync.Mutex
+	path   string
+	snipes map[string]Snipe
+}
+
+// NewSnipeStore creates a SnipeStore backed by path. Any Snipes already
+// persisted at path are loaded.
+func NewSnipeStore(path string) (*SnipeStore, error) {
+	s := &SnipeStore{
+		path:   path,
+		snipes: make(map[string]Snipe),
+	}
+
+	if err := loadJSON(path, &s.snipes); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Create assigns a new ID to snipe, persists it, and returns the stored
+// copy.
+func (s *SnipeStore) Create(snipe Snipe) (Snipe, error) {
+	id, err := newID()
+	if err != nil {
+		return Snipe{}, err
+	}
+	snipe.ID = id
+
+	s.mu.Lock()
+	s.snipes[snipe.ID] = snipe
+	s.mu.Unlock()
+
+	return snipe, s.save()
+}
+
+// List returns every scheduled Snipe, in no particular order.
+func (s *SnipeStore) List() []Snipe {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snipes := make([]Snipe, 0, len(s.snipes))
+	for _, snipe := range s.snipes {
+		snipes = append(snipes, snipe)
+	}
+	return snipes
+}
+
+// Delete removes the Snipe with the given ID. It reports whether a Snipe
+// with that ID existed.
+func (s *SnipeStore) Delete(id string) (bool, error) {
+	s.mu.Lock()
+	_, ok := s.snipes[id]
+	if ok {
+		delete(s.snipes, id)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	return true, s.save()
+}
+
+func (s *SnipeStore) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return saveJSON(s.path, s.snipes)
+}
+
+// BookingStore persists past booking confirmations to disk, so GET
+// /v1/bookings survives an API server restart.
+type BookingStore struct {
+	mu       sync.Mutex
+	path     string
+	bookings []Booking
+}
+
+// NewBookingStore creates a BookingStore backed by path. Any Bookings
+// already persisted at path are loaded.
+func NewBookingStore(path string) (*BookingStore, error) {
+	s := &BookingStore{path: path}
+
+	if err := loadJSON(path, &s.bookings); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Record appends booking to the store.
+func (s *BookingStore) Record(booking Booking) error {
+	s.mu.Lock()
+	s.bookings = append(s.bookings, booking)
+	s.mu.Unlock()
+
+	return s.save()
+}
+
+// List returns every recorded Booking, oldest first.
+func (s *BookingStore) List() []Booking {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bookings := make([]Booking, len(s.bookings))
+	copy(bookings, s.bookings)
+	return bookings
+}
+
+func (s *BookingStore) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return saveJSON(s.path, s.bookings)
+}
+
+// loadJSON unmarshals the JSON file at path into v. A missing file is not
+// an error, matching the other on-disk stores in this repo (see
+// clients.VerificationStore, clients.RateLimiter).
+func loadJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func saveJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnipeStoreCreateAssignsID tests that Create assigns a non-empty ID.
+func TestSnipeStoreCreateAssignsID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snipes.json")
+	store, err := NewSnipeStore(path)
+	require.NoError(t, err)
+
+	snipe, err := store.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025"})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, snipe.ID)
+}
+
+// TestSnipeStoreListReturnsCreated tests that a created Snipe shows up in List.
+func TestSnipeStoreListReturnsCreated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snipes.json")
+	store, err := NewSnipeStore(path)
+	require.NoError(t, err)
+
+	created, err := store.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025"})
+	require.NoError(t, err)
+
+	snipes := store.List()
+	require.Len(t, snipes, 1)
+	assert.Equal(t, created.ID, snipes[0].ID)
+}
+
+// TestSnipeStoreDeleteRemovesSnipe tests that Delete removes a Snipe and
+// reports it existed.
+func TestSnipeStoreDeleteRemovesSnipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snipes.json")
+	store, err := NewSnipeStore(path)
+	require.NoError(t, err)
+
+	created, err := store.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025"})
+	require.NoError(t, err)
+
+	found, err := store.Delete(created.ID)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Empty(t, store.List())
+}
+
+// TestSnipeStoreDeleteMissingReportsNotFound tests that Delete reports
+// false for an unknown ID without error.
+func TestSnipeStoreDeleteMissingReportsNotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snipes.json")
+	store, err := NewSnipeStore(path)
+	require.NoError(t, err)
+
+	found, err := store.Delete("does-not-exist")
+
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+// TestSnipeStorePersistsAcrossInstances tests that scheduled snipes
+// survive a reload.
+func TestSnipeStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snipes.json")
+	store, err := NewSnipeStore(path)
+	require.NoError(t, err)
+
+	created, err := store.Create(Snipe{CourseURL: "https://example.com", Date: "15-01-2025"})
+	require.NoError(t, err)
+
+	reloaded, err := NewSnipeStore(path)
+	require.NoError(t, err)
+
+	snipes := reloaded.List()
+	require.Len(t, snipes, 1)
+	assert.Equal(t, created.ID, snipes[0].ID)
+}
+
+// TestBookingStoreRecordAndList tests that recorded bookings are returned
+// by List.
+func TestBookingStoreRecordAndList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookings.json")
+	store, err := NewBookingStore(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Record(Booking{ID: "abc123", CourseURL: "https://example.com", Date: "15-01-2025", Time: "09:00"}))
+
+	bookings := store.List()
+	require.Len(t, bookings, 1)
+	assert.Equal(t, "abc123", bookings[0].ID)
+}
+
+// TestBookingStorePersistsAcrossInstances tests that recorded bookings
+// survive a reload.
+func TestBookingStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookings.json")
+	store, err := NewBookingStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Record(Booking{ID: "abc123"}))
+
+	reloaded, err := NewBookingStore(path)
+	require.NoError(t, err)
+
+	assert.Len(t, reloaded.List(), 1)
+}
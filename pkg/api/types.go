@@ -0,0 +1,26 @@
+package api
+
+import "time"
+
+// Snipe is a scheduled request to watch a course for availability and
+// book the first matching slot, created via POST /v1/snipes.
+type Snipe struct {
+	ID        string    `json:"id"`
+	CourseURL string    `json:"course_url"`
+	Date      string    `json:"date"`
+	Earliest  string    `json:"earliest"`
+	Latest    string    `json:"latest"`
+	Partners  []string  `json:"partners"`
+	Notify    bool      `json:"notify"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Booking is a past booking confirmation, recorded so GET /v1/bookings can
+// show what was actually booked rather than just what was requested.
+type Booking struct {
+	ID        string    `json:"id"`
+	CourseURL string    `json:"course_url"`
+	Date      string    `json:"date"`
+	Time      string    `json:"time"`
+	BookedAt  time.Time `json:"booked_at"`
+}
@@ -0,0 +1,112 @@
+// Package booker provides the process.State for the booker half of the
+// queue-based scraper/booker split: it consumes BookingIntents and calls
+// BookTimeSlot/AddPlayingPartner, and is the only process that needs to
+// hold Twilio/notifier credentials.
+package booker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/process"
+	"github.com/stebennett/tee-sniper/pkg/queue"
+)
+
+// State wires together the dependencies a booker process needs. Pass it
+// to process.MakeApp.
+type State struct {
+	BookingClient clients.BookingService
+	Notifiers     []clients.Notifier
+	Queue         queue.Queue
+
+	Username string
+	Pin      string
+	DryRun   bool
+
+	// OnBooked, if set, is called after a booking succeeds and its partner
+	// adds have been attempted (a failed partner add is only logged), so a
+	// caller can record it for later review - e.g. into an
+	// api.BookingStore - without this package needing to know about the
+	// api package's types.
+	OnBooked func(bookingID, date, time string)
+}
+
+// Provide implements process.State.
+func (s *State) Provide() []process.Component {
+	return []process.Component{&consumer{state: s}}
+}
+
+// consumer is the single Component a booker process runs.
+type consumer struct {
+	state *State
+}
+
+func (c *consumer) Name() string { return "booker.consumer" }
+
+// Run logs in once, then consumes BookingIntent messages, booking each
+// one and notifying on success. A booking failure is logged rather than
+// returned, so one bad intent doesn't take the whole consumer down.
+func (c *consumer) Run(ctx context.Context) error {
+	s := c.state
+
+	if _, err := s.BookingClient.LoginContext(ctx, s.Username, s.Pin); err != nil {
+		return err
+	}
+
+	msgs, unsubscribe, err := s.Queue.Subscribe(queue.TopicBookingIntent)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case data, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			c.handle(ctx, data)
+		}
+	}
+}
+
+func (c *consumer) handle(ctx context.Context, data []byte) {
+	var intent queue.BookingIntent
+	if err := json.Unmarshal(data, &intent); err != nil {
+		log.Printf("booker: failed to unmarshal booking intent: %s", err.Error())
+		return
+	}
+
+	s := c.state
+
+	bookingID, err := s.BookingClient.BookTimeSlotContext(ctx, intent.Slot, intent.Partners, s.DryRun)
+	if err != nil {
+		log.Printf("booker: failed to book slot %s: %s", intent.Slot.Time, err.Error())
+		return
+	}
+
+	for i, partnerID := range intent.Partners {
+		slotNumber := i + 2
+		if err := s.BookingClient.AddPlayingPartnerContext(ctx, bookingID, partnerID, slotNumber, s.DryRun); err != nil {
+			log.Printf("booker: failed to add playing partner %s to slot %d: %s", partnerID, slotNumber, err.Error())
+		}
+	}
+
+	message := fmt.Sprintf("Successfully booked tee time: %s for %d people", intent.Slot.Time, len(intent.Partners)+1)
+	for _, n := range s.Notifiers {
+		if err := n.Notify(ctx, intent.User, "Tee time booked", message, clients.PriorityDefault, s.DryRun); err != nil {
+			log.Printf("booker: failed to notify: %s", err.Error())
+		}
+	}
+
+	if s.OnBooked != nil {
+		s.OnBooked(bookingID, intent.Slot.BookingForm["date"], intent.Slot.Time)
+	}
+}
+
+var _ process.Component = (*consumer)(nil)
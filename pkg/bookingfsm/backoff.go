@@ -0,0 +1,136 @@
+package bookingfsm
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff computes the delay before a retried Action runs again. Next is
+// called with the current attempt number (0-based) and the State's
+// MinBackoff/MaxBackoff; Reset clears any accumulated state and is called
+// by Engine.Run at the start of every run, so one App's retry history never
+// leaks into the next booking attempt.
+type Backoff interface {
+	Next(attempt int, base, cap time.Duration) time.Duration
+	Reset()
+}
+
+// DecorrelatedJitterBackoff implements decorrelated-jitter exponential
+// backoff: each delay is a random value in [base, prevDelay*Multiplier),
+// capped at cap, with prevDelay carried forward between calls until
+// Reset. This spreads retries out over time instead of clustering them at
+// a fixed interval, which matters against a tee-time site that
+// rate-limits bursts of requests at booking-window open. This is the
+// default Backoff for bookingfsm.Context.
+type DecorrelatedJitterBackoff struct {
+	// Multiplier is the growth factor applied to the previous delay when
+	// computing the next one. Defaults to 3 (the value AWS's architecture
+	// blog uses) when zero.
+	Multiplier float64
+
+	prevDelay time.Duration
+}
+
+// Next implements Backoff.
+func (b *DecorrelatedJitterBackoff) Next(attempt int, base, cap time.Duration) time.Duration {
+	if b.prevDelay == 0 {
+		b.prevDelay = base
+	}
+
+	multiplier := b.Multiplier
+	if multiplier == 0 {
+		multiplier = 3
+	}
+
+	delay := randBetween(base, time.Duration(float64(b.prevDelay)*multiplier))
+	if delay > cap {
+		delay = cap
+	}
+
+	b.prevDelay = delay
+	return delay
+}
+
+// Reset implements Backoff.
+func (b *DecorrelatedJitterBackoff) Reset() {
+	b.prevDelay = 0
+}
+
+// UniformJitterBackoff picks a uniform random delay in [base, cap) on every
+// call, ignoring attempt and any prior delay. This is the retry behaviour
+// bookingfsm used before decorrelated jitter, kept for callers that relied
+// on it.
+type UniformJitterBackoff struct{}
+
+// Next implements Backoff.
+func (UniformJitterBackoff) Next(attempt int, base, cap time.Duration) time.Duration {
+	return jitteredBackoff(base, cap)
+}
+
+// Reset implements Backoff.
+func (UniformJitterBackoff) Reset() {}
+
+// ConstantBackoff always waits base, ignoring attempt and cap. Useful
+// against a booking site known to rate-limit by request rate rather than
+// burst size, where spreading retries out further doesn't help.
+type ConstantBackoff struct{}
+
+// Next implements Backoff.
+func (ConstantBackoff) Next(attempt int, base, cap time.Duration) time.Duration {
+	return base
+}
+
+// Reset implements Backoff.
+func (ConstantBackoff) Reset() {}
+
+// LinearBackoff waits base*(attempt+1), capped at cap.
+type LinearBackoff struct{}
+
+// Next implements Backoff.
+func (LinearBackoff) Next(attempt int, base, cap time.Duration) time.Duration {
+	delay := base * time.Duration(attempt+1)
+	if delay > cap {
+		delay = cap
+	}
+	return delay
+}
+
+// Reset implements Backoff.
+func (LinearBackoff) Reset() {}
+
+// BackoffFromName returns the Backoff strategy named by name, using
+// multiplier as DecorrelatedJitterBackoff's growth factor (ignored by the
+// other strategies). Falls back to DecorrelatedJitterBackoff - this
+// package's longstanding default - for an empty or unrecognized name,
+// the same leniency pkg/logger.Init gives an unrecognized log level.
+func BackoffFromName(name string, multiplier float64) Backoff {
+	switch name {
+	case "constant":
+		return ConstantBackoff{}
+	case "linear":
+		return LinearBackoff{}
+	case "uniform-jitter":
+		return UniformJitterBackoff{}
+	default:
+		return &DecorrelatedJitterBackoff{Multiplier: multiplier}
+	}
+}
+
+// randInt63n is a seam over rand.Int63n so tests can force a deterministic
+// choice within the range instead of depending on real randomness.
+var randInt63n = rand.Int63n
+
+// randBetween returns a random duration in [min, max), or min if max <= min.
+func randBetween(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(randInt63n(int64(max-min)))
+}
+
+var (
+	_ Backoff = (*DecorrelatedJitterBackoff)(nil)
+	_ Backoff = UniformJitterBackoff{}
+	_ Backoff = ConstantBackoff{}
+	_ Backoff = LinearBackoff{}
+)
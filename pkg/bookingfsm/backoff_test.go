@@ -0,0 +1,121 @@
+package bookingfsm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withFixedRandInt63n(t *testing.T, fixed func(n int64) int64) {
+	original := randInt63n
+	randInt63n = fixed
+	t.Cleanup(func() { randInt63n = original })
+}
+
+func TestDecorrelatedJitterBackoffGrowsTowardCapWhenAlwaysMax(t *testing.T) {
+	withFixedRandInt63n(t, func(n int64) int64 { return n - 1 })
+
+	backoff := &DecorrelatedJitterBackoff{}
+	base := time.Second
+	cap := 10 * time.Second
+
+	var delays []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		delays = append(delays, backoff.Next(attempt, base, cap))
+	}
+
+	for i := 1; i < len(delays); i++ {
+		assert.GreaterOrEqual(t, delays[i], delays[i-1])
+	}
+	assert.Equal(t, cap, delays[len(delays)-1])
+}
+
+func TestDecorrelatedJitterBackoffNeverExceedsCap(t *testing.T) {
+	withFixedRandInt63n(t, func(n int64) int64 { return n - 1 })
+
+	backoff := &DecorrelatedJitterBackoff{}
+	base := time.Second
+	cap := 3 * time.Second
+
+	for attempt := 0; attempt < 10; attempt++ {
+		assert.LessOrEqual(t, backoff.Next(attempt, base, cap), cap)
+	}
+}
+
+func TestDecorrelatedJitterBackoffResetClearsPrevDelay(t *testing.T) {
+	withFixedRandInt63n(t, func(n int64) int64 { return n - 1 })
+
+	backoff := &DecorrelatedJitterBackoff{}
+	base := time.Second
+	cap := 10 * time.Second
+
+	first := backoff.Next(0, base, cap)
+	backoff.Next(1, base, cap)
+	backoff.Reset()
+	afterReset := backoff.Next(0, base, cap)
+
+	assert.Equal(t, first, afterReset)
+}
+
+func TestUniformJitterBackoffReturnsValueWithinRange(t *testing.T) {
+	backoff := UniformJitterBackoff{}
+	base := time.Second
+	cap := 5 * time.Second
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := backoff.Next(attempt, base, cap)
+		assert.GreaterOrEqual(t, delay, base)
+		assert.LessOrEqual(t, delay, cap)
+	}
+}
+
+func TestDecorrelatedJitterBackoffRespectsCustomMultiplier(t *testing.T) {
+	withFixedRandInt63n(t, func(n int64) int64 { return n - 1 })
+
+	backoff := &DecorrelatedJitterBackoff{Multiplier: 1}
+	base := time.Second
+	cap := 10 * time.Second
+
+	for attempt := 0; attempt < 5; attempt++ {
+		assert.Equal(t, base, backoff.Next(attempt, base, cap))
+	}
+}
+
+func TestConstantBackoffAlwaysReturnsBase(t *testing.T) {
+	backoff := ConstantBackoff{}
+	base := 2 * time.Second
+	cap := 10 * time.Second
+
+	for attempt := 0; attempt < 5; attempt++ {
+		assert.Equal(t, base, backoff.Next(attempt, base, cap))
+	}
+}
+
+func TestLinearBackoffGrowsWithAttemptAndCaps(t *testing.T) {
+	backoff := LinearBackoff{}
+	base := time.Second
+	cap := 3 * time.Second
+
+	assert.Equal(t, time.Second, backoff.Next(0, base, cap))
+	assert.Equal(t, 2*time.Second, backoff.Next(1, base, cap))
+	assert.Equal(t, cap, backoff.Next(2, base, cap))
+}
+
+func TestBackoffFromNameSelectsStrategy(t *testing.T) {
+	assert.IsType(t, ConstantBackoff{}, BackoffFromName("constant", 3))
+	assert.IsType(t, LinearBackoff{}, BackoffFromName("linear", 3))
+	assert.IsType(t, UniformJitterBackoff{}, BackoffFromName("uniform-jitter", 3))
+	assert.IsType(t, &DecorrelatedJitterBackoff{}, BackoffFromName("decorrelated-jitter", 3))
+	assert.IsType(t, &DecorrelatedJitterBackoff{}, BackoffFromName("", 3))
+	assert.IsType(t, &DecorrelatedJitterBackoff{}, BackoffFromName("unknown", 3))
+}
+
+func TestBackoffFromNamePassesMultiplierThrough(t *testing.T) {
+	backoff := BackoffFromName("decorrelated-jitter", 5)
+
+	djb, ok := backoff.(*DecorrelatedJitterBackoff)
+	require.True(t, ok)
+	assert.Equal(t, 5.0, djb.Multiplier)
+}
@@ -0,0 +1,302 @@
+package bookingfsm
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stebennett/tee-sniper/pkg/teetimes"
+)
+
+// Context carries everything the booking flow's Actions need: the client
+// to drive, the parameters of the search, and the state accumulated as
+// the flow progresses (chosen date, slot, booking ID). It is deliberately
+// generic enough that future flows built on this package - cancellation,
+// waitlist - can reuse the same shape rather than inventing their own.
+type Context struct {
+	BookingClient clients.BookingService
+
+	// Ctx is the base context the flow's Actions derive their per-request
+	// contexts from - e.g. actionBook bounds the booking attempt to just
+	// before the chosen tee-off time. Defaults to context.Background()
+	// when nil, so callers that don't set it see unchanged behavior.
+	Ctx context.Context
+
+	Username        string
+	Pin             string
+	DaysAhead       int
+	TimeStart       string
+	TimeEnd         string
+	DryRun          bool
+	PlayingPartners []string
+
+	Now   func() time.Time
+	Sleep func(time.Duration)
+
+	// Backoff computes the delay between in-place retries (see
+	// StateDef.RetryOn). Defaults to a DecorrelatedJitterBackoff when nil.
+	Backoff Backoff
+
+	// OnBooked, if set, is called once a booking (and any partner adds)
+	// succeeds, so a caller can notify/record it without this package
+	// needing to know about clients.Notifier or any particular store.
+	OnBooked func(dateStr string, slot models.TimeSlot, partners []string)
+
+	// OnRetry, if set, is called just before Engine.Run sleeps for an
+	// in-place retry (see StateDef.RetryOn), so a caller can record the
+	// delay (e.g. to a metrics.Metrics.RetryDelay histogram) or log it
+	// without this package needing to know about either.
+	OnRetry func(state State, attempt int, delay time.Duration)
+
+	// Scorer picks the bookable slot actionSelectSlot chooses when more
+	// than one passes the window filter. Defaults to a bare
+	// teetimes.PreferredTimeScorer when nil, so an unconfigured Context
+	// keeps picking deterministically rather than at random: with no
+	// PreferredTime set every slot scores 0, and teetimes.PickBestTime's
+	// tie-break picks the earliest.
+	Scorer teetimes.Scorer
+
+	// ScoringPreferences is passed to Scorer as the
+	// teetimes.ScoringContext's preference fields (DateStr is filled in
+	// from ctx.DateStr automatically).
+	ScoringPreferences teetimes.ScoringContext
+
+	DateStr        string
+	AvailableTimes []models.TimeSlot
+	ChosenSlot     models.TimeSlot
+	BookingID      string
+
+	retryCounts map[State]int
+}
+
+func (c *Context) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+func (c *Context) sleep(d time.Duration) {
+	if c.Sleep == nil {
+		time.Sleep(d)
+		return
+	}
+	c.Sleep(d)
+}
+
+func (c *Context) backoff() Backoff {
+	if c.Backoff == nil {
+		c.Backoff = &DecorrelatedJitterBackoff{}
+	}
+	return c.Backoff
+}
+
+func (c *Context) scorer() teetimes.Scorer {
+	if c.Scorer == nil {
+		return teetimes.PreferredTimeScorer{}
+	}
+	return c.Scorer
+}
+
+// scoringContext returns c.ScoringPreferences with DateStr filled in from
+// c.DateStr, so callers only need to set the preference fields.
+func (c *Context) scoringContext() teetimes.ScoringContext {
+	sctx := c.ScoringPreferences
+	sctx.DateStr = c.DateStr
+	return sctx
+}
+
+func (c *Context) baseCtx() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
+}
+
+// BookingStates returns the State definitions for the tee-time booking
+// flow: Init -> LoggedIn -> FetchedAvailability -> SlotSelected -> Booked
+// -> PartnersAdded -> Notified -> Done, with NoAvailability,
+// BookingRejected, PartnerAddFailed and Failed as the terminal failure
+// outcomes. retries is the per-state retry budget for transient failures,
+// taken from the same Config.Retries an operator already tunes today.
+func BookingStates(retries int) map[State]StateDef {
+	return map[State]StateDef{
+		StateInit: {
+			Action: actionLogin,
+			Transitions: map[Event]State{
+				EventLoggedIn:       StateLoggedIn,
+				EventTransientError: StateFailed,
+			},
+			RetryOn:    map[Event]bool{EventTransientError: true},
+			MaxRetries: 2,
+			MinBackoff: 3 * time.Second,
+			MaxBackoff: 8 * time.Second,
+		},
+		StateLoggedIn: {
+			Action: actionFetchAvailability,
+			Transitions: map[Event]State{
+				EventSlotsFound:     StateFetchedAvailability,
+				EventNoSlots:        StateNoAvailability,
+				EventTransientError: StateFailed,
+			},
+			RetryOn:    map[Event]bool{EventNoSlots: true},
+			MaxRetries: retries,
+			MinBackoff: 5 * time.Second,
+			MaxBackoff: 15 * time.Second,
+		},
+		StateFetchedAvailability: {
+			Action: actionSelectSlot,
+			Transitions: map[Event]State{
+				EventSlotChosen: StateSlotSelected,
+				EventNoSlots:    StateNoAvailability,
+			},
+		},
+		StateSlotSelected: {
+			Action: actionBook,
+			Transitions: map[Event]State{
+				EventBooked:          StateBooked,
+				EventBookingRejected: StateBookingRejected,
+				EventTransientError:  StateBookingRejected,
+			},
+			RetryOn:    map[Event]bool{EventBookingRejected: true, EventTransientError: true},
+			MaxRetries: retries,
+			MinBackoff: 3 * time.Second,
+			MaxBackoff: 10 * time.Second,
+		},
+		StateBooked: {
+			Action: actionAddPartners,
+			Transitions: map[Event]State{
+				EventPartnersAdded:    StatePartnersAdded,
+				EventPartnerAddFailed: StatePartnerAddFailed,
+			},
+		},
+		StatePartnersAdded: {
+			Action: actionNotify,
+			Transitions: map[Event]State{
+				EventNotified: StateNotified,
+			},
+		},
+		StateNotified: {
+			Action: func(ctx *Context) Event { return EventDone },
+			Transitions: map[Event]State{
+				EventDone: StateDone,
+			},
+		},
+	}
+}
+
+// sessionChecker is implemented by clients.BookingClient when constructed
+// via clients.NewBookingClientWithSession; actionLogin uses it to skip a
+// full re-login when the cookies persisted from a prior run are still
+// good, saving the login round-trip at booking-window open.
+type sessionChecker interface {
+	SessionValid(ctx context.Context) (bool, error)
+}
+
+func actionLogin(ctx *Context) Event {
+	if sc, ok := ctx.BookingClient.(sessionChecker); ok {
+		if valid, err := sc.SessionValid(ctx.baseCtx()); err == nil && valid {
+			ctx.DateStr = ctx.now().AddDate(0, 0, ctx.DaysAhead).Format("02-01-2006")
+			return EventLoggedIn
+		}
+	}
+
+	if _, err := ctx.BookingClient.LoginContext(ctx.baseCtx(), ctx.Username, ctx.Pin); err != nil {
+		log.Printf("bookingfsm: login failed: %s", err.Error())
+		return EventTransientError
+	}
+
+	ctx.DateStr = ctx.now().AddDate(0, 0, ctx.DaysAhead).Format("02-01-2006")
+	return EventLoggedIn
+}
+
+func actionFetchAvailability(ctx *Context) Event {
+	availableTimes, err := ctx.BookingClient.GetCourseAvailabilityContext(ctx.baseCtx(), ctx.DateStr)
+	if err != nil {
+		log.Printf("bookingfsm: failed to get availability: %s", err.Error())
+		return EventTransientError
+	}
+
+	availableTimes = teetimes.FilterByBookable(availableTimes)
+	availableTimes = teetimes.SortTimesAscending(availableTimes)
+	availableTimes = teetimes.FilterBetweenTimes(availableTimes, ctx.TimeStart, ctx.TimeEnd)
+
+	if len(availableTimes) == 0 {
+		log.Printf("bookingfsm: no tee times available between %s and %s on %s", ctx.TimeStart, ctx.TimeEnd, ctx.DateStr)
+		return EventNoSlots
+	}
+
+	log.Printf("bookingfsm: found %d available tee times between %s and %s on %s", len(availableTimes), ctx.TimeStart, ctx.TimeEnd, ctx.DateStr)
+	ctx.AvailableTimes = availableTimes
+	return EventSlotsFound
+}
+
+func actionSelectSlot(ctx *Context) Event {
+	slot, err := teetimes.PickBestTime(ctx.AvailableTimes, ctx.scorer(), ctx.scoringContext())
+	if err != nil {
+		log.Printf("bookingfsm: failed to pick a tee time: %s", err.Error())
+		return EventNoSlots
+	}
+
+	ctx.ChosenSlot = slot
+	return EventSlotChosen
+}
+
+func actionBook(ctx *Context) Event {
+	log.Printf("bookingfsm: attempting to book tee time: %s on %s for %d people", ctx.ChosenSlot.Time, ctx.DateStr, len(ctx.PlayingPartners)+1)
+
+	// Bound the booking attempt to just before the chosen tee-off time, so
+	// a stalled request is abandoned in time to retry against the next
+	// slot instead of missing the whole window.
+	bookCtx := ctx.baseCtx()
+	if teeOff, err := time.Parse("02-01-2006 15:04", ctx.DateStr+" "+ctx.ChosenSlot.Time); err == nil {
+		var cancel context.CancelFunc
+		bookCtx, cancel = context.WithDeadline(bookCtx, teeOff.Add(-1*time.Second))
+		defer cancel()
+	}
+
+	bookingID, err := ctx.BookingClient.BookTimeSlotContext(bookCtx, ctx.ChosenSlot, ctx.PlayingPartners, ctx.DryRun)
+	if err != nil {
+		log.Printf("bookingfsm: failed to book time slot: %s", err.Error())
+		return EventTransientError
+	}
+	if bookingID == "" {
+		log.Printf("bookingfsm: failed to complete booking: %s on %s", ctx.ChosenSlot.Time, ctx.DateStr)
+		return EventBookingRejected
+	}
+
+	log.Printf("bookingfsm: successfully booked tee time: %s on %s (booking ID: %s)", ctx.ChosenSlot.Time, ctx.DateStr, bookingID)
+	ctx.BookingID = bookingID
+	return EventBooked
+}
+
+func actionAddPartners(ctx *Context) Event {
+	if len(ctx.PlayingPartners) == 0 {
+		return EventPartnersAdded
+	}
+
+	failures := 0
+	for i, partnerID := range ctx.PlayingPartners {
+		slotNumber := i + 2
+		if err := ctx.BookingClient.AddPlayingPartner(ctx.BookingID, partnerID, slotNumber, ctx.DryRun); err != nil {
+			log.Printf("bookingfsm: failed to add playing partner %s to slot %d: %s", partnerID, slotNumber, err.Error())
+			failures++
+		} else {
+			log.Printf("bookingfsm: added playing partner %s to slot %d", partnerID, slotNumber)
+		}
+	}
+
+	if failures == len(ctx.PlayingPartners) {
+		return EventPartnerAddFailed
+	}
+	return EventPartnersAdded
+}
+
+func actionNotify(ctx *Context) Event {
+	if ctx.OnBooked != nil {
+		ctx.OnBooked(ctx.DateStr, ctx.ChosenSlot, ctx.PlayingPartners)
+	}
+	return EventNotified
+}
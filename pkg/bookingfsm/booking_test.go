@@ -0,0 +1,221 @@
+package bookingfsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBookingService is a test double for clients.BookingService.
+type fakeBookingService struct {
+	loginErr error
+
+	availability    []models.TimeSlot
+	availabilityErr error
+
+	bookingID  string
+	bookErr    error
+	partnerErr map[string]error
+}
+
+func (f *fakeBookingService) Login(username, pin string) (bool, error) {
+	return f.loginErr == nil, f.loginErr
+}
+
+func (f *fakeBookingService) LoginContext(ctx context.Context, username, pin string) (bool, error) {
+	return f.Login(username, pin)
+}
+
+func (f *fakeBookingService) GetCourseAvailability(dateStr string) ([]models.TimeSlot, error) {
+	return f.availability, f.availabilityErr
+}
+
+func (f *fakeBookingService) GetCourseAvailabilityContext(ctx context.Context, dateStr string) ([]models.TimeSlot, error) {
+	return f.GetCourseAvailability(dateStr)
+}
+
+func (f *fakeBookingService) BookTimeSlot(slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.bookingID, f.bookErr
+}
+
+func (f *fakeBookingService) BookTimeSlotContext(ctx context.Context, slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.BookTimeSlot(slot, partners, dryRun)
+}
+
+func (f *fakeBookingService) AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.partnerErr[partnerID]
+}
+
+func (f *fakeBookingService) AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.AddPlayingPartner(bookingID, partnerID, slotNumber, dryRun)
+}
+
+func testContext(client *fakeBookingService) *Context {
+	return &Context{
+		BookingClient: client,
+		Username:      "testuser",
+		Pin:           "1234",
+		DaysAhead:     7,
+		TimeStart:     "09:00",
+		TimeEnd:       "17:00",
+		Now:           func() time.Time { return time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC) },
+		Sleep:         func(d time.Duration) {},
+	}
+}
+
+func TestBookingFlowSucceeds(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+	}
+	ctx := testContext(client)
+
+	var bookedDate string
+	var bookedSlot models.TimeSlot
+	ctx.OnBooked = func(dateStr string, slot models.TimeSlot, partners []string) {
+		bookedDate = dateStr
+		bookedSlot = slot
+	}
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, "22-01-2024", bookedDate)
+	assert.Equal(t, "10:00", bookedSlot.Time)
+	assert.Equal(t, "booking-123", ctx.BookingID)
+}
+
+func TestBookingFlowAddsPartners(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+	}
+	ctx := testContext(client)
+	ctx.PlayingPartners = []string{"partner1", "partner2"}
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+}
+
+func TestBookingFlowPartialPartnerFailureStillSucceeds(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+		partnerErr:   map[string]error{"partner1": errors.New("partner not found")},
+	}
+	ctx := testContext(client)
+	ctx.PlayingPartners = []string{"partner1", "partner2"}
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+}
+
+func TestBookingFlowAllPartnerFailuresAreTerminal(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+		partnerErr:   map[string]error{"partner1": errors.New("partner not found")},
+	}
+	ctx := testContext(client)
+	ctx.PlayingPartners = []string{"partner1"}
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatePartnerAddFailed, final)
+}
+
+func TestBookingFlowLoginErrorIsTerminal(t *testing.T) {
+	client := &fakeBookingService{loginErr: errors.New("invalid credentials")}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateFailed, final)
+}
+
+func TestBookingFlowRetriesOnNoAvailabilityThenSucceeds(t *testing.T) {
+	calls := 0
+	client := &fakeBookingService{bookingID: "booking-123"}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(2))
+	engine.States[StateLoggedIn] = StateDef{
+		Action: func(ctx *Context) Event {
+			calls++
+			if calls == 1 {
+				return EventNoSlots
+			}
+			ctx.AvailableTimes = []models.TimeSlot{{Time: "10:00", CanBook: true}}
+			return EventSlotsFound
+		},
+		Transitions: engine.States[StateLoggedIn].Transitions,
+		RetryOn:     engine.States[StateLoggedIn].RetryOn,
+		MaxRetries:  engine.States[StateLoggedIn].MaxRetries,
+	}
+
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, 2, calls)
+}
+
+func TestBookingFlowNoAvailabilityExhaustsRetries(t *testing.T) {
+	client := &fakeBookingService{availability: []models.TimeSlot{}}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(1))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateNoAvailability, final)
+}
+
+func TestBookingFlowEmptyBookingIDIsRejected(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "",
+	}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(0))
+	final, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateBookingRejected, final)
+}
+
+func TestBookingFlowRecordsTrace(t *testing.T) {
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+	}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(3))
+	_, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	require.Len(t, engine.Trace, 7)
+	assert.Equal(t, StateInit, engine.Trace[0].State)
+	assert.Equal(t, EventLoggedIn, engine.Trace[0].Event)
+	assert.Equal(t, StateNotified, engine.Trace[6].State)
+	assert.Equal(t, EventDone, engine.Trace[6].Event)
+}
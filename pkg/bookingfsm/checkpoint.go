@@ -0,0 +1,98 @@
+package bookingfsm
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+)
+
+// Checkpoint is the on-disk snapshot of a run, written after every
+// transition so a crashed process can resume from its last reached State
+// instead of starting over from StateInit.
+type Checkpoint struct {
+	State          State
+	DateStr        string
+	AvailableTimes []models.TimeSlot
+	ChosenSlot     models.TimeSlot
+	BookingID      string
+	Trace          BookingTrace
+}
+
+// SaveCheckpoint writes cp to path as JSON.
+func SaveCheckpoint(path string, cp Checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadCheckpoint reads a Checkpoint previously written by SaveCheckpoint.
+// ok is false, with a nil error, when path does not exist.
+func LoadCheckpoint(path string) (cp Checkpoint, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Checkpoint{}, false, nil
+		}
+		return Checkpoint{}, false, err
+	}
+
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return Checkpoint{}, false, err
+	}
+	return cp, true, nil
+}
+
+// checkpoint persists the run's progress to e.CheckpointPath, if set. It
+// removes the file once current is terminal, since a finished run - won
+// or lost - has nothing left to resume.
+func (e *Engine) checkpoint(ctx *Context, current State) {
+	if e.CheckpointPath == "" {
+		return
+	}
+
+	if IsTerminal(current) {
+		if err := os.Remove(e.CheckpointPath); err != nil && !os.IsNotExist(err) {
+			log.Printf("bookingfsm: failed to remove checkpoint: %s", err.Error())
+		}
+		return
+	}
+
+	cp := Checkpoint{
+		State:          current,
+		DateStr:        ctx.DateStr,
+		AvailableTimes: ctx.AvailableTimes,
+		ChosenSlot:     ctx.ChosenSlot,
+		BookingID:      ctx.BookingID,
+		Trace:          e.Trace,
+	}
+	if err := SaveCheckpoint(e.CheckpointPath, cp); err != nil {
+		log.Printf("bookingfsm: failed to persist checkpoint: %s", err.Error())
+	}
+}
+
+// Resume starts ctx from the State last checkpointed at path, if one
+// exists, falling back to a fresh run at StateInit otherwise. It also
+// arranges for Run to keep checkpointing to path as it progresses.
+func (e *Engine) Resume(ctx *Context, path string) (State, error) {
+	e.CheckpointPath = path
+
+	start := StateInit
+	cp, ok, err := LoadCheckpoint(path)
+	if err != nil {
+		log.Printf("bookingfsm: failed to load checkpoint, starting fresh: %s", err.Error())
+	} else if ok {
+		ctx.DateStr = cp.DateStr
+		ctx.AvailableTimes = cp.AvailableTimes
+		ctx.ChosenSlot = cp.ChosenSlot
+		ctx.BookingID = cp.BookingID
+		e.Trace = cp.Trace
+		start = cp.State
+		log.Printf("bookingfsm: resuming booking run from state %q", start)
+	}
+
+	return e.Run(ctx, start)
+}
@@ -0,0 +1,104 @@
+package bookingfsm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoadCheckpointRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cp := Checkpoint{
+		State:      StateSlotSelected,
+		DateStr:    "22-01-2024",
+		ChosenSlot: models.TimeSlot{Time: "10:00"},
+	}
+
+	require.NoError(t, SaveCheckpoint(path, cp))
+
+	loaded, ok, err := LoadCheckpoint(path)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, cp, loaded)
+}
+
+func TestLoadCheckpointMissingFileIsNotAnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	_, ok, err := LoadCheckpoint(path)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestEngineResumeStartsFreshWithoutExistingCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+	}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Resume(ctx, path)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	_, ok, err := LoadCheckpoint(path)
+	require.NoError(t, err)
+	assert.False(t, ok, "checkpoint should be removed once the run reaches a terminal state")
+}
+
+func TestEngineResumeContinuesFromCheckpointedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, SaveCheckpoint(path, Checkpoint{
+		State:      StateSlotSelected,
+		DateStr:    "22-01-2024",
+		ChosenSlot: models.TimeSlot{Time: "10:00", CanBook: true},
+	}))
+
+	client := &fakeBookingService{bookingID: "booking-123"}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(3))
+	final, err := engine.Resume(ctx, path)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, "22-01-2024", ctx.DateStr)
+}
+
+func TestEngineRunPersistsCheckpointOnEachTransition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	client := &fakeBookingService{
+		availability: []models.TimeSlot{{Time: "10:00", CanBook: true}},
+		bookingID:    "booking-123",
+	}
+	ctx := testContext(client)
+
+	engine := NewEngine(BookingStates(3))
+	engine.CheckpointPath = path
+
+	seenCheckpoint := false
+	checkAction := engine.States[StateSlotSelected].Action
+	engine.States[StateSlotSelected] = StateDef{
+		Action: func(ctx *Context) Event {
+			if _, err := os.Stat(path); err == nil {
+				seenCheckpoint = true
+			}
+			return checkAction(ctx)
+		},
+		Transitions: engine.States[StateSlotSelected].Transitions,
+		RetryOn:     engine.States[StateSlotSelected].RetryOn,
+		MaxRetries:  engine.States[StateSlotSelected].MaxRetries,
+	}
+
+	_, err := engine.Run(ctx, StateInit)
+
+	require.NoError(t, err)
+	assert.True(t, seenCheckpoint, "checkpoint file should exist by the time a later state runs")
+}
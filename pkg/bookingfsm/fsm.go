@@ -0,0 +1,163 @@
+// Package bookingfsm models the tee-time booking workflow as an explicit
+// finite state machine, replacing the ad-hoc retry loop that used to live
+// directly in cmd/tee-sniper's App.Run. Each State has an Action that does
+// the work and reports back an Event; the Engine looks up the next State
+// for that Event and records every hop into a BookingTrace, so a failed
+// run can be inspected (or resumed - see Engine.Resume) instead of just
+// logging its way into the void.
+package bookingfsm
+
+import (
+	"fmt"
+	"time"
+)
+
+// State names a step in the booking workflow.
+type State string
+
+const (
+	StateInit                State = "Init"
+	StateLoggedIn            State = "LoggedIn"
+	StateFetchedAvailability State = "FetchedAvailability"
+	StateSlotSelected        State = "SlotSelected"
+	StateBooked              State = "Booked"
+	StatePartnersAdded       State = "PartnersAdded"
+	StateNotified            State = "Notified"
+	StateDone                State = "Done"
+
+	// StateNoAvailability, StateBookingRejected and StatePartnerAddFailed
+	// are the named failure outcomes the request called for; StateFailed
+	// is the catch-all for everything else (e.g. a login error).
+	StateNoAvailability   State = "NoAvailability"
+	StateBookingRejected  State = "BookingRejected"
+	StatePartnerAddFailed State = "PartnerAddFailed"
+	StateFailed           State = "Failed"
+)
+
+var terminalStates = map[State]bool{
+	StateDone:             true,
+	StateNoAvailability:   true,
+	StateBookingRejected:  true,
+	StatePartnerAddFailed: true,
+	StateFailed:           true,
+}
+
+// IsTerminal reports whether a run stops once it reaches State s.
+func IsTerminal(s State) bool {
+	return terminalStates[s]
+}
+
+// Event is what an Action reports back to the Engine, which looks it up
+// in the current State's Transitions to find the next State.
+type Event string
+
+const (
+	EventLoggedIn         Event = "LoggedIn"
+	EventSlotsFound       Event = "SlotsFound"
+	EventNoSlots          Event = "NoSlots"
+	EventSlotChosen       Event = "SlotChosen"
+	EventBooked           Event = "Booked"
+	EventBookingRejected  Event = "BookingRejected"
+	EventPartnersAdded    Event = "PartnersAdded"
+	EventPartnerAddFailed Event = "PartnerAddFailed"
+	EventNotified         Event = "Notified"
+	EventDone             Event = "Done"
+	EventTransientError   Event = "TransientError"
+)
+
+// Action runs the work for a State and reports which Event happened.
+type Action func(ctx *Context) Event
+
+// StateDef is one State's behaviour. RetryOn marks Events that should be
+// retried in place - after a jittered backoff, up to MaxRetries times -
+// before falling through to Transitions, so retry policy is declared per
+// state instead of hand-rolled in a loop.
+type StateDef struct {
+	Action      Action
+	Transitions map[Event]State
+
+	RetryOn    map[Event]bool
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Transition is one recorded step of a run.
+type Transition struct {
+	State State
+	Event Event
+	At    time.Time
+}
+
+// BookingTrace is the ordered history of transitions taken during a run,
+// suitable for dumping on failure or exposing via a status endpoint.
+type BookingTrace []Transition
+
+// Engine drives a Context through a set of States, recording every
+// transition it takes into Trace. Set CheckpointPath to persist progress
+// to disk as the run goes, so a crash can be resumed - see Resume.
+type Engine struct {
+	States         map[State]StateDef
+	Trace          BookingTrace
+	CheckpointPath string
+}
+
+// NewEngine builds an Engine over the given State definitions.
+func NewEngine(states map[State]StateDef) *Engine {
+	return &Engine{States: states}
+}
+
+// Run drives ctx through the state machine starting at start, until it
+// reaches a terminal State (see IsTerminal) or a State/Event combination
+// with no definition, which is returned as an error.
+func (e *Engine) Run(ctx *Context, start State) (State, error) {
+	if ctx.retryCounts == nil {
+		ctx.retryCounts = map[State]int{}
+	}
+	ctx.backoff().Reset()
+
+	current := start
+	for {
+		def, ok := e.States[current]
+		if !ok {
+			return current, fmt.Errorf("bookingfsm: no definition for state %q", current)
+		}
+
+		event := def.Action(ctx)
+		e.Trace = append(e.Trace, Transition{State: current, Event: event, At: ctx.now()})
+
+		if def.RetryOn[event] {
+			attempt := ctx.retryCounts[current]
+			if attempt < def.MaxRetries {
+				ctx.retryCounts[current] = attempt + 1
+				delay := ctx.backoff().Next(attempt, def.MinBackoff, def.MaxBackoff)
+				if ctx.OnRetry != nil {
+					ctx.OnRetry(current, attempt, delay)
+				}
+				ctx.sleep(delay)
+				continue
+			}
+		}
+
+		next, ok := def.Transitions[event]
+		if !ok {
+			return current, fmt.Errorf("bookingfsm: state %q has no transition for event %q", current, event)
+		}
+
+		current = next
+		e.checkpoint(ctx, current)
+		if IsTerminal(current) {
+			return current, nil
+		}
+	}
+}
+
+// jitteredBackoff picks a random delay in [min, max), or min if max <= min. It backs
+// UniformJitterBackoff - see backoff.go for the DecorrelatedJitterBackoff
+// that Context uses by default.
+func jitteredBackoff(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(randInt63n(int64(max-min)))
+}
@@ -0,0 +1,214 @@
+package bookingfsm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stateA State = "A"
+	stateB State = "B"
+
+	eventOK    Event = "OK"
+	eventRetry Event = "Retry"
+)
+
+func TestEngineRunFollowsTransitions(t *testing.T) {
+	states := map[State]StateDef{
+		stateA: {
+			Action:      func(ctx *Context) Event { return eventOK },
+			Transitions: map[Event]State{eventOK: stateB},
+		},
+		stateB: {
+			Action:      func(ctx *Context) Event { return EventDone },
+			Transitions: map[Event]State{EventDone: StateDone},
+		},
+	}
+
+	engine := NewEngine(states)
+	final, err := engine.Run(&Context{}, stateA)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, []Transition{
+		{State: stateA, Event: eventOK},
+		{State: stateB, Event: EventDone},
+	}, stripTimestamps(engine.Trace))
+}
+
+func TestEngineRunRetriesInPlaceUntilExhausted(t *testing.T) {
+	attempts := 0
+	states := map[State]StateDef{
+		stateA: {
+			Action: func(ctx *Context) Event {
+				attempts++
+				if attempts <= 2 {
+					return eventRetry
+				}
+				return eventOK
+			},
+			Transitions: map[Event]State{eventOK: StateDone, eventRetry: StateFailed},
+			RetryOn:     map[Event]bool{eventRetry: true},
+			MaxRetries:  2,
+		},
+	}
+
+	var slept []time.Duration
+	ctx := &Context{Sleep: func(d time.Duration) { slept = append(slept, d) }}
+
+	engine := NewEngine(states)
+	final, err := engine.Run(ctx, stateA)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, slept, 2)
+}
+
+// fakeBackoff is a test double for Backoff that records whether Reset was
+// called and how many times Next was called.
+type fakeBackoff struct {
+	resetCalled bool
+	nextCalls   int
+}
+
+func (f *fakeBackoff) Next(attempt int, base, cap time.Duration) time.Duration {
+	f.nextCalls++
+	return 0
+}
+
+func (f *fakeBackoff) Reset() {
+	f.resetCalled = true
+}
+
+func TestEngineRunResetsBackoffAtStartOfRun(t *testing.T) {
+	states := map[State]StateDef{
+		stateA: {
+			Action:      func(ctx *Context) Event { return EventDone },
+			Transitions: map[Event]State{EventDone: StateDone},
+		},
+	}
+
+	backoff := &fakeBackoff{}
+	ctx := &Context{Backoff: backoff}
+
+	engine := NewEngine(states)
+	_, err := engine.Run(ctx, stateA)
+
+	require.NoError(t, err)
+	assert.True(t, backoff.resetCalled)
+}
+
+func TestEngineRunUsesBackoffForRetryDelays(t *testing.T) {
+	attempts := 0
+	states := map[State]StateDef{
+		stateA: {
+			Action: func(ctx *Context) Event {
+				attempts++
+				if attempts <= 2 {
+					return eventRetry
+				}
+				return eventOK
+			},
+			Transitions: map[Event]State{eventOK: StateDone, eventRetry: StateFailed},
+			RetryOn:     map[Event]bool{eventRetry: true},
+			MaxRetries:  2,
+			MinBackoff:  time.Second,
+			MaxBackoff:  5 * time.Second,
+		},
+	}
+
+	backoff := &fakeBackoff{}
+	ctx := &Context{Backoff: backoff, Sleep: func(d time.Duration) {}}
+
+	engine := NewEngine(states)
+	final, err := engine.Run(ctx, stateA)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, 2, backoff.nextCalls)
+}
+
+func TestEngineRunCallsOnRetryBeforeEachSleep(t *testing.T) {
+	attempts := 0
+	states := map[State]StateDef{
+		stateA: {
+			Action: func(ctx *Context) Event {
+				attempts++
+				if attempts <= 2 {
+					return eventRetry
+				}
+				return eventOK
+			},
+			Transitions: map[Event]State{eventOK: StateDone, eventRetry: StateFailed},
+			RetryOn:     map[Event]bool{eventRetry: true},
+			MaxRetries:  2,
+			MinBackoff:  time.Second,
+			MaxBackoff:  5 * time.Second,
+		},
+	}
+
+	var retryAttempts []int
+	ctx := &Context{
+		Sleep: func(d time.Duration) {},
+		OnRetry: func(state State, attempt int, delay time.Duration) {
+			retryAttempts = append(retryAttempts, attempt)
+		},
+	}
+
+	engine := NewEngine(states)
+	final, err := engine.Run(ctx, stateA)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, final)
+	assert.Equal(t, []int{0, 1}, retryAttempts)
+}
+
+func TestEngineRunFallsThroughOnceRetriesExhausted(t *testing.T) {
+	states := map[State]StateDef{
+		stateA: {
+			Action:      func(ctx *Context) Event { return eventRetry },
+			Transitions: map[Event]State{eventRetry: StateFailed},
+			RetryOn:     map[Event]bool{eventRetry: true},
+			MaxRetries:  1,
+		},
+	}
+
+	ctx := &Context{Sleep: func(d time.Duration) {}}
+
+	engine := NewEngine(states)
+	final, err := engine.Run(ctx, stateA)
+
+	require.NoError(t, err)
+	assert.Equal(t, StateFailed, final)
+}
+
+func TestEngineRunErrorsOnUndefinedState(t *testing.T) {
+	engine := NewEngine(map[State]StateDef{})
+	_, err := engine.Run(&Context{}, stateA)
+	assert.Error(t, err)
+}
+
+func TestEngineRunErrorsOnUnhandledEvent(t *testing.T) {
+	states := map[State]StateDef{
+		stateA: {
+			Action:      func(ctx *Context) Event { return eventOK },
+			Transitions: map[Event]State{},
+		},
+	}
+
+	engine := NewEngine(states)
+	_, err := engine.Run(&Context{}, stateA)
+	assert.Error(t, err)
+}
+
+func stripTimestamps(trace BookingTrace) []Transition {
+	stripped := make([]Transition, len(trace))
+	for i, tr := range trace {
+		stripped[i] = Transition{State: tr.State, Event: tr.Event}
+	}
+	return stripped
+}
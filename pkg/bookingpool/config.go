@@ -0,0 +1,57 @@
+package bookingpool
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+// BackendConfig is one course/club's connection details and priority
+// weight. A backends file holds a list of these - one per course a Pool
+// should target.
+type BackendConfig struct {
+	Name     string `yaml:"name"`
+	BaseUrl  string `yaml:"base_url"`
+	Username string `yaml:"username"`
+	Pin      string `yaml:"pin"`
+	Weight   int    `yaml:"weight"`
+}
+
+// LoadBackendConfigs reads a YAML file containing a list of BackendConfig
+// entries.
+func LoadBackendConfigs(path string) ([]BackendConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []BackendConfig
+	if err := yaml.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("bookingpool: failed to parse %s: %w", path, err)
+	}
+	return configs, nil
+}
+
+// NewPoolFromConfigs builds a Pool with one Backend per BackendConfig,
+// each talking to its own clients.NewBookingClient.
+func NewPoolFromConfigs(configs []BackendConfig) (*Pool, error) {
+	backends := make([]*Backend, 0, len(configs))
+	for _, cfg := range configs {
+		client, err := clients.NewBookingClient(cfg.BaseUrl)
+		if err != nil {
+			return nil, fmt.Errorf("bookingpool: failed to build client for backend %q: %w", cfg.Name, err)
+		}
+
+		backends = append(backends, &Backend{
+			Name:     cfg.Name,
+			Client:   client,
+			Username: cfg.Username,
+			Pin:      cfg.Pin,
+			Weight:   cfg.Weight,
+		})
+	}
+	return NewPool(backends), nil
+}
@@ -0,0 +1,55 @@
+package bookingpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBackendConfigsParsesYamlList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backends.yaml")
+	yaml := `
+- name: club-a
+  base_url: https://club-a.example.com
+  username: user-a
+  pin: "1111"
+  weight: 3
+- name: club-b
+  base_url: https://club-b.example.com
+  username: user-b
+  pin: "2222"
+  weight: 1
+`
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0600))
+
+	configs, err := LoadBackendConfigs(path)
+
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "club-a", configs[0].Name)
+	assert.Equal(t, 3, configs[0].Weight)
+	assert.Equal(t, "club-b", configs[1].Name)
+}
+
+func TestLoadBackendConfigsMissingFileReturnsError(t *testing.T) {
+	_, err := LoadBackendConfigs(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	assert.Error(t, err)
+}
+
+func TestNewPoolFromConfigsBuildsOneBackendPerConfig(t *testing.T) {
+	configs := []BackendConfig{
+		{Name: "club-a", BaseUrl: "https://club-a.example.com", Username: "user-a", Pin: "1111", Weight: 3},
+		{Name: "club-b", BaseUrl: "https://club-b.example.com", Username: "user-b", Pin: "2222"},
+	}
+
+	pool, err := NewPoolFromConfigs(configs)
+
+	require.NoError(t, err)
+	require.Len(t, pool.Backends, 2)
+	assert.Equal(t, "club-a", pool.Backends[0].Name)
+	assert.Equal(t, 3, pool.Backends[0].Weight)
+	assert.Equal(t, "club-b", pool.Backends[1].Name)
+}
@@ -0,0 +1,190 @@
+// Package bookingpool lets App target more than one course/club in a
+// single run. A Pool wraps N clients.BookingService backends, each with
+// its own credentials and priority Weight; Probe health-checks them all
+// up front, and Next samples a healthy one, weighted towards higher
+// priority, so a failing or slow course doesn't block the others.
+package bookingpool
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+// degradedCooldown is how long a backend that just failed a booking
+// attempt is skipped before being offered again by Next.
+const degradedCooldown = 5 * time.Minute
+
+// Backend is one course/club a Pool can book against.
+type Backend struct {
+	Name     string
+	Client   clients.BookingService
+	Username string
+	Pin      string
+	Weight   int
+
+	mu            sync.Mutex
+	healthy       bool
+	degradedUntil time.Time
+	attempts      int
+	successes     int
+	lastSuccess   time.Time
+}
+
+// Stats is a point-in-time snapshot of a Backend's health and attempt
+// counts, suitable for including in a booking summary notification.
+type Stats struct {
+	Name        string
+	Healthy     bool
+	Attempts    int
+	Successes   int
+	LastSuccess time.Time
+}
+
+// BackendSelector picks one Backend to try next out of a list of
+// candidates, all of which are already known to be healthy.
+type BackendSelector interface {
+	Select(candidates []*Backend) *Backend
+}
+
+// WeightedSelector picks a Backend at random, weighted by Backend.Weight,
+// so a higher-priority course is preferred without ever starving the
+// rest. A non-positive Weight is treated as 1.
+type WeightedSelector struct{}
+
+// Select implements BackendSelector.
+func (WeightedSelector) Select(candidates []*Backend) *Backend {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, c := range candidates {
+		total += weightOf(c)
+	}
+
+	pick := rand.Intn(total)
+	for _, c := range candidates {
+		w := weightOf(c)
+		if pick < w {
+			return c
+		}
+		pick -= w
+	}
+	return candidates[len(candidates)-1]
+}
+
+func weightOf(b *Backend) int {
+	if b.Weight <= 0 {
+		return 1
+	}
+	return b.Weight
+}
+
+var _ BackendSelector = WeightedSelector{}
+
+// Pool wraps a set of Backends and routes each booking attempt to the
+// best healthy one via Selector.
+type Pool struct {
+	Backends []*Backend
+	Selector BackendSelector
+
+	// Now defaults to time.Now; tests override it to control when a
+	// degraded backend becomes eligible again.
+	Now func() time.Time
+}
+
+// NewPool builds a Pool over backends using a WeightedSelector.
+func NewPool(backends []*Backend) *Pool {
+	return &Pool{Backends: backends, Selector: WeightedSelector{}, Now: time.Now}
+}
+
+// Single wraps a single clients.BookingService in a one-backend Pool, for
+// callers that don't need multi-course support - e.g. the common
+// single-club configuration.
+func Single(name, username, pin string, client clients.BookingService) *Pool {
+	return NewPool([]*Backend{{Name: name, Client: client, Username: username, Pin: pin, Weight: 1, healthy: true}})
+}
+
+func (p *Pool) now() time.Time {
+	if p.Now == nil {
+		return time.Now()
+	}
+	return p.Now()
+}
+
+// Probe logs into every backend and fetches availability for dateStr as a
+// lightweight health check, marking any that fail as unhealthy so Next
+// samples them out until a later Probe succeeds.
+func (p *Pool) Probe(ctx context.Context, dateStr string) {
+	for _, b := range p.Backends {
+		healthy := true
+		if _, err := b.Client.LoginContext(ctx, b.Username, b.Pin); err != nil {
+			healthy = false
+		} else if _, err := b.Client.GetCourseAvailabilityContext(ctx, dateStr); err != nil {
+			healthy = false
+		}
+
+		b.mu.Lock()
+		b.healthy = healthy
+		b.mu.Unlock()
+	}
+}
+
+// Next returns the best healthy Backend to try, weighted by priority, or
+// nil if none are currently healthy.
+func (p *Pool) Next() *Backend {
+	now := p.now()
+
+	var candidates []*Backend
+	for _, b := range p.Backends {
+		b.mu.Lock()
+		ok := b.healthy && now.After(b.degradedUntil)
+		b.mu.Unlock()
+		if ok {
+			candidates = append(candidates, b)
+		}
+	}
+
+	return p.Selector.Select(candidates)
+}
+
+// MarkSuccess records a successful booking against b.
+func (p *Pool) MarkSuccess(b *Backend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts++
+	b.successes++
+	b.lastSuccess = p.now()
+}
+
+// MarkFailure records a failed booking against b and puts it into a
+// cooldown, so the next Next call routes around it rather than retrying
+// the same course immediately.
+func (p *Pool) MarkFailure(b *Backend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts++
+	b.degradedUntil = p.now().Add(degradedCooldown)
+}
+
+// Stats returns a snapshot of every backend's health and attempt counts.
+func (p *Pool) Stats() []Stats {
+	now := p.now()
+	stats := make([]Stats, len(p.Backends))
+	for i, b := range p.Backends {
+		b.mu.Lock()
+		stats[i] = Stats{
+			Name:        b.Name,
+			Healthy:     b.healthy && now.After(b.degradedUntil),
+			Attempts:    b.attempts,
+			Successes:   b.successes,
+			LastSuccess: b.lastSuccess,
+		}
+		b.mu.Unlock()
+	}
+	return stats
+}
@@ -0,0 +1,134 @@
+package bookingpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBookingService is a test double for clients.BookingService.
+type fakeBookingService struct {
+	loginErr        error
+	availability    []models.TimeSlot
+	availabilityErr error
+}
+
+func (f *fakeBookingService) Login(username, pin string) (bool, error) {
+	return f.loginErr == nil, f.loginErr
+}
+
+func (f *fakeBookingService) LoginContext(ctx context.Context, username, pin string) (bool, error) {
+	return f.Login(username, pin)
+}
+
+func (f *fakeBookingService) GetCourseAvailability(dateStr string) ([]models.TimeSlot, error) {
+	return f.availability, f.availabilityErr
+}
+
+func (f *fakeBookingService) GetCourseAvailabilityContext(ctx context.Context, dateStr string) ([]models.TimeSlot, error) {
+	return f.GetCourseAvailability(dateStr)
+}
+
+func (f *fakeBookingService) BookTimeSlot(slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return "", nil
+}
+
+func (f *fakeBookingService) BookTimeSlotContext(ctx context.Context, slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.BookTimeSlot(slot, partners, dryRun)
+}
+
+func (f *fakeBookingService) AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return nil
+}
+
+func (f *fakeBookingService) AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.AddPlayingPartner(bookingID, partnerID, slotNumber, dryRun)
+}
+
+func TestPoolProbeMarksFailingBackendsUnhealthy(t *testing.T) {
+	good := &Backend{Name: "good", Client: &fakeBookingService{}}
+	bad := &Backend{Name: "bad", Client: &fakeBookingService{loginErr: assert.AnError}}
+
+	pool := NewPool([]*Backend{good, bad})
+	pool.Probe(context.Background(), "01-01-2026")
+
+	assert.Equal(t, good, pool.Next())
+
+	stats := pool.Stats()
+	require.Len(t, stats, 2)
+	assert.True(t, stats[0].Healthy)
+	assert.False(t, stats[1].Healthy)
+}
+
+func TestPoolNextReturnsNilWhenNoBackendsAreHealthy(t *testing.T) {
+	bad := &Backend{Name: "bad", Client: &fakeBookingService{loginErr: assert.AnError}}
+
+	pool := NewPool([]*Backend{bad})
+	pool.Probe(context.Background(), "01-01-2026")
+
+	assert.Nil(t, pool.Next())
+}
+
+func TestPoolMarkFailureRoutesAroundBackendUntilCooldownExpires(t *testing.T) {
+	a := &Backend{Name: "a", Client: &fakeBookingService{}, healthy: true}
+	b := &Backend{Name: "b", Client: &fakeBookingService{}, healthy: true}
+
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	pool := &Pool{Backends: []*Backend{a, b}, Selector: WeightedSelector{}, Now: func() time.Time { return now }}
+
+	pool.MarkFailure(a)
+	assert.Equal(t, b, pool.Next())
+
+	now = now.Add(degradedCooldown + time.Second)
+	candidates := map[*Backend]bool{}
+	for i := 0; i < 20; i++ {
+		candidates[pool.Next()] = true
+	}
+	assert.True(t, candidates[a], "expected backend a to become eligible again after its cooldown elapsed")
+}
+
+func TestPoolMarkSuccessRecordsStats(t *testing.T) {
+	a := &Backend{Name: "a", Client: &fakeBookingService{}, healthy: true}
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	pool := &Pool{Backends: []*Backend{a}, Selector: WeightedSelector{}, Now: func() time.Time { return now }}
+
+	pool.MarkSuccess(a)
+
+	stats := pool.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, 1, stats[0].Attempts)
+	assert.Equal(t, 1, stats[0].Successes)
+	assert.Equal(t, now, stats[0].LastSuccess)
+}
+
+func TestWeightedSelectorPrefersHigherWeightBackend(t *testing.T) {
+	low := &Backend{Name: "low", Weight: 1}
+	high := &Backend{Name: "high", Weight: 99}
+
+	selector := WeightedSelector{}
+	highCount := 0
+	for i := 0; i < 200; i++ {
+		if selector.Select([]*Backend{low, high}) == high {
+			highCount++
+		}
+	}
+
+	assert.Greater(t, highCount, 150)
+}
+
+func TestWeightedSelectorReturnsNilForNoCandidates(t *testing.T) {
+	selector := WeightedSelector{}
+	assert.Nil(t, selector.Select(nil))
+}
+
+func TestSingleBuildsOneBackendHealthyPool(t *testing.T) {
+	client := &fakeBookingService{}
+	pool := Single("my-club", "user", "1234", client)
+
+	require.Len(t, pool.Backends, 1)
+	assert.Equal(t, client, pool.Next().Client)
+}
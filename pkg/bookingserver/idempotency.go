@@ -0,0 +1,28 @@
+package bookingserver
+
+import "sync"
+
+// idempotencyCache remembers the Booking produced for a client-supplied
+// idempotency key, so a retried CreateBooking call returns the original
+// result instead of booking the same slot twice.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	results map[string]Booking
+}
+
+func newIdempotencyCache() *idempotencyCache {
+	return &idempotencyCache{results: make(map[string]Booking)}
+}
+
+func (c *idempotencyCache) get(key string) (Booking, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.results[key]
+	return b, ok
+}
+
+func (c *idempotencyCache) put(key string, b Booking) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[key] = b
+}
@@ -0,0 +1,347 @@
+// Package bookingserver exposes the booking workflow as a long-lived
+// service, with an RPC surface modeled on the Maps Booking Partner v2
+// shape (CheckAvailability, CreateBooking, UpdateBooking, CancelBooking,
+// a health check, and BookingHistory), so the same clients.BookingService
+// that powers the one-shot CLI also backs a persistent server.
+//
+// The request asked for this to be exposed over gRPC. Generating and
+// vendoring a real protobuf/grpc-go stack isn't possible in this
+// environment - no protoc, no network to fetch and checksum
+// google.golang.org/grpc - so, following the same tradeoff pkg/queue
+// already made for its pub/sub backend, this ships the same RPC shape
+// over HTTP/JSON instead, using the pkg/api convention already
+// established for the snipe-scheduling control plane. A real gRPC
+// transport can be layered on top of Service later without touching any
+// booking logic.
+package bookingserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/models"
+)
+
+// ErrBookingNotFound is returned by UpdateBooking and CancelBooking when
+// id does not match a recorded Booking.
+var ErrBookingNotFound = errors.New("bookingserver: booking not found")
+
+// Sink receives a structured event for every booking outcome (created,
+// failed, cancelled), so operators can pipe them into SMS, a webhook, or
+// a pub/sub system later without Service depending on any one of them.
+// See NotifierSink to adapt an existing clients.Notifier.
+type Sink interface {
+	Notify(ctx context.Context, subject, body string) error
+}
+
+// NotifierSink adapts a clients.Notifier (which needs a recipient,
+// priority, and dry-run flag) into a Sink.
+type NotifierSink struct {
+	Notifier  clients.Notifier
+	Recipient string
+	DryRun    bool
+}
+
+// Notify implements Sink.
+func (n NotifierSink) Notify(ctx context.Context, subject, body string) error {
+	return n.Notifier.Notify(ctx, n.Recipient, subject, body, clients.PriorityDefault, n.DryRun)
+}
+
+// MultiSink fans a single Notify out to every Sink in Sinks, so a caller
+// running more than one notifier backend (e.g. clients.NotifiersFromConfig
+// returning both Twilio and ntfy) can still hand Service one Sink. A
+// failing backend is logged and skipped rather than stopping the others,
+// matching notify.FanOut's best-effort delivery.
+type MultiSink struct {
+	Sinks []Sink
+}
+
+// Notify implements Sink.
+func (m MultiSink) Notify(ctx context.Context, subject, body string) error {
+	for _, sink := range m.Sinks {
+		if err := sink.Notify(ctx, subject, body); err != nil {
+			log.Printf("bookingserver: sink failed to deliver notification: %v", err)
+		}
+	}
+	return nil
+}
+
+// Service implements the booking RPCs against a single
+// clients.BookingService backend, recording every outcome in History and
+// de-duplicating retried CreateBooking calls by idempotency key.
+type Service struct {
+	Client  clients.BookingService
+	History *HistoryStore
+	Sink    Sink
+
+	idempotency *idempotencyCache
+}
+
+// NewService creates a Service. sink may be nil, in which case booking
+// events are simply not published anywhere.
+func NewService(client clients.BookingService, history *HistoryStore, sink Sink) *Service {
+	return &Service{Client: client, History: history, Sink: sink, idempotency: newIdempotencyCache()}
+}
+
+// CheckAvailability returns bookable slots for date, restricted to
+// [earliest, latest) when those are non-empty.
+func (s *Service) CheckAvailability(ctx context.Context, date, earliest, latest string) ([]Slot, error) {
+	times, err := s.Client.GetCourseAvailabilityContext(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]Slot, 0, len(times))
+	for _, t := range times {
+		if earliest != "" && t.Time < earliest {
+			continue
+		}
+		if latest != "" && t.Time >= latest {
+			continue
+		}
+		slots = append(slots, Slot{Time: t.Time, CanBook: t.CanBook})
+	}
+	return slots, nil
+}
+
+// CreateBooking books slotTime on date for partySize (the requester plus
+// partners). A retried call with the same non-empty idempotencyKey
+// returns the original Booking rather than booking the slot twice.
+func (s *Service) CreateBooking(ctx context.Context, date, slotTime string, partySize int, partners []string, idempotencyKey string) (Booking, error) {
+	if idempotencyKey != "" {
+		if existing, ok := s.idempotency.get(idempotencyKey); ok {
+			return existing, nil
+		}
+	}
+
+	bookingID, err := s.Client.BookTimeSlotContext(ctx, models.TimeSlot{Time: slotTime, CanBook: true}, partners, false)
+	if err != nil {
+		s.notify(ctx, "Booking failed", fmt.Sprintf("Failed to book %s on %s: %s", slotTime, date, err.Error()))
+		return Booking{}, err
+	}
+
+	booking := Booking{
+		ID:        bookingID,
+		Date:      date,
+		Time:      slotTime,
+		PartySize: partySize,
+		Partners:  partners,
+		Status:    BookingStatusCreated,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.History.Record(booking); err != nil {
+		return Booking{}, err
+	}
+
+	if idempotencyKey != "" {
+		s.idempotency.put(idempotencyKey, booking)
+	}
+
+	s.notify(ctx, "Booking created", fmt.Sprintf("Booked %s on %s for %d", slotTime, date, partySize))
+	return booking, nil
+}
+
+// UpdateBooking changes the party size and partners on an existing
+// Booking.
+func (s *Service) UpdateBooking(ctx context.Context, id string, partySize int, partners []string) (Booking, error) {
+	booking, found, err := s.History.Update(id, func(b *Booking) {
+		b.PartySize = partySize
+		b.Partners = partners
+	})
+	if err != nil {
+		return Booking{}, err
+	}
+	if !found {
+		return Booking{}, ErrBookingNotFound
+	}
+	return booking, nil
+}
+
+// CancelBooking marks a Booking as cancelled.
+func (s *Service) CancelBooking(ctx context.Context, id string) (Booking, error) {
+	booking, found, err := s.History.Update(id, func(b *Booking) { b.Status = BookingStatusCancelled })
+	if err != nil {
+		return Booking{}, err
+	}
+	if !found {
+		return Booking{}, ErrBookingNotFound
+	}
+
+	s.notify(ctx, "Booking cancelled", fmt.Sprintf("Cancelled booking %s", id))
+	return booking, nil
+}
+
+// BookingHistory returns every Booking ever created or cancelled via this
+// Service, oldest first.
+func (s *Service) BookingHistory() []Booking {
+	return s.History.List()
+}
+
+func (s *Service) notify(ctx context.Context, subject, body string) {
+	if s.Sink == nil {
+		return
+	}
+	_ = s.Sink.Notify(ctx, subject, body)
+}
+
+// Server exposes Service's RPCs over HTTP/JSON:
+//
+//	GET    /v1/availability?date=...&earliest=...&latest=...  CheckAvailability
+//	POST   /v1/bookings                                        CreateBooking
+//	GET    /v1/bookings                                        BookingHistory
+//	PATCH  /v1/bookings/{id}                                   UpdateBooking
+//	DELETE /v1/bookings/{id}                                   CancelBooking
+//	GET    /healthz                                            health check
+//
+// POST /v1/bookings honors an `Idempotency-Key` header exactly like
+// Service.CreateBooking.
+type Server struct {
+	Service *Service
+}
+
+// NewServer creates a Server backed by service.
+func NewServer(service *Service) *Server {
+	return &Server{Service: service}
+}
+
+// Handler returns the http.Handler serving the server's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/availability", s.handleCheckAvailability)
+	mux.HandleFunc("/v1/bookings", s.handleBookingsCollection)
+	mux.HandleFunc("/v1/bookings/", s.handleBookingItem)
+	mux.HandleFunc("/healthz", s.handleHealth)
+
+	return mux
+}
+
+func (s *Server) handleCheckAvailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	slots, err := s.Service.CheckAvailability(r.Context(), q.Get("date"), q.Get("earliest"), q.Get("latest"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, slots)
+}
+
+func (s *Server) handleBookingsCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.Service.BookingHistory())
+	case http.MethodPost:
+		s.handleCreateBooking(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// createBookingRequest is the POST /v1/bookings request body.
+type createBookingRequest struct {
+	Date      string   `json:"date"`
+	Time      string   `json:"time"`
+	PartySize int      `json:"party_size"`
+	Partners  []string `json:"partners"`
+}
+
+func (s *Server) handleCreateBooking(w http.ResponseWriter, r *http.Request) {
+	var req createBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	booking, err := s.Service.CreateBooking(r.Context(), req.Date, req.Time, req.PartySize, req.Partners, r.Header.Get("Idempotency-Key"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, booking)
+}
+
+// updateBookingRequest is the PATCH /v1/bookings/{id} request body.
+type updateBookingRequest struct {
+	PartySize int      `json:"party_size"`
+	Partners  []string `json:"partners"`
+}
+
+func (s *Server) handleBookingItem(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/v1/bookings/")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		s.handleUpdateBooking(w, r, id)
+	case http.MethodDelete:
+		s.handleCancelBooking(w, r, id)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleUpdateBooking(w http.ResponseWriter, r *http.Request, id string) {
+	var req updateBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	booking, err := s.Service.UpdateBooking(r.Context(), id, req.PartySize, req.Partners)
+	if errors.Is(err, ErrBookingNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, booking)
+}
+
+func (s *Server) handleCancelBooking(w http.ResponseWriter, r *http.Request, id string) {
+	booking, err := s.Service.CancelBooking(r.Context(), id)
+	if errors.Is(err, ErrBookingNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, booking)
+}
+
+// handleHealth serves a minimal stand-in for the requested grpc_health_v1
+// endpoint - "SERVING" is always returned, since Server has no
+// dependencies of its own to report as unhealthy beyond the process being
+// up.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "SERVING"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,112 @@
+package bookingserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, client *fakeBookingService) *Server {
+	t.Helper()
+	history, err := NewHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+	require.NoError(t, err)
+	return NewServer(NewService(client, history, nil))
+}
+
+func TestHandleCheckAvailabilityFiltersByQueryWindow(t *testing.T) {
+	server := newTestServer(t, &fakeBookingService{availability: []models.TimeSlot{
+		{Time: "08:00", CanBook: true},
+		{Time: "10:00", CanBook: true},
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/availability?date=15-01-2025&earliest=09:00", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var slots []Slot
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&slots))
+	require.Len(t, slots, 1)
+	assert.Equal(t, "10:00", slots[0].Time)
+}
+
+func TestHandleCreateBookingReturnsCreated(t *testing.T) {
+	server := newTestServer(t, &fakeBookingService{bookingID: "booking-123"})
+
+	body := `{"date":"15-01-2025","time":"10:00","party_size":2,"partners":["p1"]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/bookings", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var booking Booking
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&booking))
+	assert.Equal(t, "booking-123", booking.ID)
+}
+
+func TestHandleListBookingsReturnsHistory(t *testing.T) {
+	server := newTestServer(t, &fakeBookingService{bookingID: "booking-123"})
+	createReq := httptest.NewRequest(http.MethodPost, "/v1/bookings", bytes.NewBufferString(`{"date":"15-01-2025","time":"10:00"}`))
+	server.Handler().ServeHTTP(httptest.NewRecorder(), createReq)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/bookings", nil)
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var bookings []Booking
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&bookings))
+	require.Len(t, bookings, 1)
+}
+
+func TestHandleUpdateBookingUnknownIDReturnsNotFound(t *testing.T) {
+	server := newTestServer(t, &fakeBookingService{})
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/bookings/does-not-exist", bytes.NewBufferString(`{}`))
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleCancelBookingMarksCancelled(t *testing.T) {
+	server := newTestServer(t, &fakeBookingService{bookingID: "booking-123"})
+	createReq := httptest.NewRequest(http.MethodPost, "/v1/bookings", bytes.NewBufferString(`{"date":"15-01-2025","time":"10:00"}`))
+	createW := httptest.NewRecorder()
+	server.Handler().ServeHTTP(createW, createReq)
+	var created Booking
+	require.NoError(t, json.NewDecoder(createW.Body).Decode(&created))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/bookings/"+created.ID, nil)
+	w := httptest.NewRecorder()
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var cancelled Booking
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&cancelled))
+	assert.Equal(t, BookingStatusCancelled, cancelled.Status)
+}
+
+func TestHandleHealthReturnsServing(t *testing.T) {
+	server := newTestServer(t, &fakeBookingService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, "SERVING", body["status"])
+}
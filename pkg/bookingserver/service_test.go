@@ -0,0 +1,160 @@
+package bookingserver
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBookingService is a test double for clients.BookingService.
+type fakeBookingService struct {
+	availability []models.TimeSlot
+	bookingID    string
+	bookErr      error
+}
+
+func (f *fakeBookingService) Login(username, pin string) (bool, error) { return true, nil }
+func (f *fakeBookingService) LoginContext(ctx context.Context, username, pin string) (bool, error) {
+	return f.Login(username, pin)
+}
+
+func (f *fakeBookingService) GetCourseAvailability(dateStr string) ([]models.TimeSlot, error) {
+	return f.availability, nil
+}
+func (f *fakeBookingService) GetCourseAvailabilityContext(ctx context.Context, dateStr string) ([]models.TimeSlot, error) {
+	return f.GetCourseAvailability(dateStr)
+}
+
+func (f *fakeBookingService) BookTimeSlot(slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.bookingID, f.bookErr
+}
+func (f *fakeBookingService) BookTimeSlotContext(ctx context.Context, slot models.TimeSlot, partners []string, dryRun bool) (string, error) {
+	return f.BookTimeSlot(slot, partners, dryRun)
+}
+
+func (f *fakeBookingService) AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return nil
+}
+func (f *fakeBookingService) AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return f.AddPlayingPartner(bookingID, partnerID, slotNumber, dryRun)
+}
+
+// fakeSink is a test double for Sink.
+type fakeSink struct {
+	calls []string
+}
+
+func (f *fakeSink) Notify(ctx context.Context, subject, body string) error {
+	f.calls = append(f.calls, subject)
+	return nil
+}
+
+func newTestService(t *testing.T, client *fakeBookingService, sink Sink) *Service {
+	t.Helper()
+	history, err := NewHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+	require.NoError(t, err)
+	return NewService(client, history, sink)
+}
+
+func TestCheckAvailabilityFiltersByTimeWindow(t *testing.T) {
+	client := &fakeBookingService{availability: []models.TimeSlot{
+		{Time: "08:00", CanBook: true},
+		{Time: "10:00", CanBook: true},
+		{Time: "14:00", CanBook: false},
+	}}
+	service := newTestService(t, client, nil)
+
+	slots, err := service.CheckAvailability(context.Background(), "15-01-2025", "09:00", "12:00")
+
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	assert.Equal(t, "10:00", slots[0].Time)
+}
+
+func TestCreateBookingRecordsHistoryAndNotifiesSink(t *testing.T) {
+	client := &fakeBookingService{bookingID: "booking-123"}
+	sink := &fakeSink{}
+	service := newTestService(t, client, sink)
+
+	booking, err := service.CreateBooking(context.Background(), "15-01-2025", "10:00", 2, []string{"p1"}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "booking-123", booking.ID)
+	assert.Equal(t, BookingStatusCreated, booking.Status)
+	assert.Len(t, service.BookingHistory(), 1)
+	assert.Equal(t, []string{"Booking created"}, sink.calls)
+}
+
+func TestCreateBookingWithSameIdempotencyKeyIsNotBookedTwice(t *testing.T) {
+	client := &fakeBookingService{bookingID: "booking-123"}
+	service := newTestService(t, client, nil)
+
+	first, err := service.CreateBooking(context.Background(), "15-01-2025", "10:00", 1, nil, "retry-key")
+	require.NoError(t, err)
+
+	second, err := service.CreateBooking(context.Background(), "15-01-2025", "10:00", 1, nil, "retry-key")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Len(t, service.BookingHistory(), 1)
+}
+
+func TestCreateBookingFailureNotifiesSinkAndReturnsError(t *testing.T) {
+	client := &fakeBookingService{bookErr: errors.New("course full")}
+	sink := &fakeSink{}
+	service := newTestService(t, client, sink)
+
+	_, err := service.CreateBooking(context.Background(), "15-01-2025", "10:00", 1, nil, "")
+
+	assert.Error(t, err)
+	assert.Empty(t, service.BookingHistory())
+	assert.Equal(t, []string{"Booking failed"}, sink.calls)
+}
+
+func TestUpdateBookingChangesPartySizeAndPartners(t *testing.T) {
+	client := &fakeBookingService{bookingID: "booking-123"}
+	service := newTestService(t, client, nil)
+	created, err := service.CreateBooking(context.Background(), "15-01-2025", "10:00", 1, nil, "")
+	require.NoError(t, err)
+
+	updated, err := service.UpdateBooking(context.Background(), created.ID, 3, []string{"p1", "p2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.PartySize)
+	assert.Equal(t, []string{"p1", "p2"}, updated.Partners)
+}
+
+func TestUpdateBookingUnknownIDReturnsNotFound(t *testing.T) {
+	service := newTestService(t, &fakeBookingService{}, nil)
+
+	_, err := service.UpdateBooking(context.Background(), "does-not-exist", 1, nil)
+
+	assert.ErrorIs(t, err, ErrBookingNotFound)
+}
+
+func TestCancelBookingMarksStatusAndNotifiesSink(t *testing.T) {
+	client := &fakeBookingService{bookingID: "booking-123"}
+	sink := &fakeSink{}
+	service := newTestService(t, client, sink)
+	created, err := service.CreateBooking(context.Background(), "15-01-2025", "10:00", 1, nil, "")
+	require.NoError(t, err)
+
+	cancelled, err := service.CancelBooking(context.Background(), created.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, BookingStatusCancelled, cancelled.Status)
+	assert.Equal(t, []string{"Booking created", "Booking cancelled"}, sink.calls)
+}
+
+func TestCancelBookingUnknownIDReturnsNotFound(t *testing.T) {
+	service := newTestService(t, &fakeBookingService{}, nil)
+
+	_, err := service.CancelBooking(context.Background(), "does-not-exist")
+
+	assert.ErrorIs(t, err, ErrBookingNotFound)
+}
@@ -0,0 +1,99 @@
+package bookingserver
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// HistoryStore persists every Booking ever created or cancelled to disk,
+// backing the BookingHistory RPC and surviving a server restart.
+type HistoryStore struct {
+	mu       sync.Mutex
+	path     string
+	bookings []Booking
+}
+
+// NewHistoryStore creates a HistoryStore backed by path. Any Bookings
+// already persisted at path are loaded.
+func NewHistoryStore(path string) (*HistoryStore, error) {
+	s := &HistoryStore{path: path}
+
+	if err := loadJSON(path, &s.bookings); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Record appends booking to the store.
+func (s *HistoryStore) Record(booking Booking) error {
+	s.mu.Lock()
+	s.bookings = append(s.bookings, booking)
+	s.mu.Unlock()
+
+	return s.save()
+}
+
+// Update applies mutate to the Booking with the given ID and persists the
+// result, reporting whether a Booking with that ID was found.
+func (s *HistoryStore) Update(id string, mutate func(*Booking)) (Booking, bool, error) {
+	s.mu.Lock()
+	index := -1
+	for i, b := range s.bookings {
+		if b.ID == id {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		s.mu.Unlock()
+		return Booking{}, false, nil
+	}
+
+	mutate(&s.bookings[index])
+	updated := s.bookings[index]
+	s.mu.Unlock()
+
+	return updated, true, s.save()
+}
+
+// List returns every recorded Booking, oldest first.
+func (s *HistoryStore) List() []Booking {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bookings := make([]Booking, len(s.bookings))
+	copy(bookings, s.bookings)
+	return bookings
+}
+
+func (s *HistoryStore) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return saveJSON(s.path, s.bookings)
+}
+
+// loadJSON unmarshals the JSON file at path into v. A missing file is not
+// an error, matching the other on-disk stores in this repo (see
+// api.BookingStore, clients.VerificationStore).
+func loadJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func saveJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
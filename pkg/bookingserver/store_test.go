@@ -0,0 +1,58 @@
+package bookingserver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryStoreRecordAndList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	store, err := NewHistoryStore(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Record(Booking{ID: "booking-123", Time: "10:00"}))
+
+	bookings := store.List()
+	require.Len(t, bookings, 1)
+	assert.Equal(t, "booking-123", bookings[0].ID)
+}
+
+func TestHistoryStoreUpdateMutatesMatchingBooking(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	store, err := NewHistoryStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Record(Booking{ID: "booking-123", PartySize: 1}))
+
+	updated, found, err := store.Update("booking-123", func(b *Booking) { b.PartySize = 4 })
+
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 4, updated.PartySize)
+	assert.Equal(t, 4, store.List()[0].PartySize)
+}
+
+func TestHistoryStoreUpdateMissingReportsNotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	store, err := NewHistoryStore(path)
+	require.NoError(t, err)
+
+	_, found, err := store.Update("does-not-exist", func(b *Booking) {})
+
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestHistoryStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	store, err := NewHistoryStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Record(Booking{ID: "booking-123"}))
+
+	reloaded, err := NewHistoryStore(path)
+	require.NoError(t, err)
+
+	assert.Len(t, reloaded.List(), 1)
+}
@@ -0,0 +1,28 @@
+package bookingserver
+
+import "time"
+
+// Booking statuses recorded in a HistoryStore.
+const (
+	BookingStatusCreated   = "created"
+	BookingStatusCancelled = "cancelled"
+)
+
+// Slot is one bookable (or unbookable) tee time returned by
+// CheckAvailability.
+type Slot struct {
+	Time    string `json:"time"`
+	CanBook bool   `json:"can_book"`
+}
+
+// Booking is a confirmed or cancelled booking made via Service, recorded
+// so BookingHistory can list past RPCs.
+type Booking struct {
+	ID        string    `json:"id"`
+	Date      string    `json:"date"`
+	Time      string    `json:"time"`
+	PartySize int       `json:"party_size"`
+	Partners  []string  `json:"partners"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
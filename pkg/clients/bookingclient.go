@@ -1,14 +1,18 @@
 package clients
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/cookiejar"
+	"net/http/httptrace"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stebennett/tee-sniper/pkg/models"
@@ -21,27 +25,78 @@ var (
 )
 
 type BookingClient struct {
-	baseUrl    string
-	httpClient *http.Client
+	baseUrl     string
+	httpClient  *http.Client
+	sessionPath string
+
+	// RetryPolicy controls the exponential backoff applied to transient
+	// failures. Defaults to DefaultRetryPolicy().
+	RetryPolicy RetryPolicy
+
+	// Course labels this client's observations in Metrics and Event, for
+	// deployments juggling more than one course. Defaults to "".
+	Course string
+
+	// Metrics receives structured observations (login attempts,
+	// availability fetches, booking attempts, partner adds) at each key
+	// point in the workflow. Defaults to a no-op implementation; see
+	// pkg/clients/metrics for a Prometheus-backed one.
+	Metrics Metrics
+
+	// EventHook, if set, is called with every observation alongside
+	// Metrics, for callers who want to pipe events into their own logger
+	// or webhook instead of Prometheus.
+	EventHook EventHook
 }
 
+// NewBookingClient creates a BookingClient with a default transport: no
+// proxy override beyond the environment, no TLS customization, and no
+// request timeout. See NewBookingClientWithOptions to run behind a corporate
+// proxy, pin a TLS certificate, or inject an instrumented RoundTripper.
 func NewBookingClient(u string) (*BookingClient, error) {
+	return NewBookingClientWithOptions(u, ClientOptions{})
+}
+
+// NewBookingClientWithOptions creates a BookingClient whose HTTP transport is
+// configured by opts, so the client can run behind a corporate proxy, pin
+// the club's TLS certificate, or have logging/tracing/mock middleware
+// wrapped around every outbound request.
+func NewBookingClientWithOptions(u string, opts ClientOptions) (*BookingClient, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &http.Client{
-		Jar: jar,
+		Jar:       jar,
+		Transport: opts.buildTransport(),
+		Timeout:   opts.Timeout,
 	}
 
 	return &BookingClient{
-		baseUrl:    u,
-		httpClient: client,
+		baseUrl:     u,
+		httpClient:  client,
+		RetryPolicy: DefaultRetryPolicy(),
+		Metrics:     noopMetrics{},
 	}, nil
 }
 
+// Login authenticates with the booking site using context.Background().
+// See LoginContext for the context-aware variant.
 func (w BookingClient) Login(username string, password string) (bool, error) {
+	return w.LoginContext(context.Background(), username, password)
+}
+
+// LoginContext authenticates with the booking site. The request is bound to
+// ctx so a caller can abort a slow login (e.g. when a tee time is no longer
+// worth chasing).
+func (w BookingClient) LoginContext(ctx context.Context, username string, password string) (success bool, err error) {
+	start := time.Now()
+	defer func() {
+		w.Metrics.LoginAttempt(success, time.Since(start))
+		w.emit(Event{Type: EventLoginAttempt, Course: w.Course, Success: success, Duration: time.Since(start)})
+	}()
+
 	form := url.Values{}
 	form.Add("task", "login")
 	form.Add("topmenu", "1")
@@ -52,14 +107,25 @@ func (w BookingClient) Login(username string, password string) (bool, error) {
 
 	url := fmt.Sprintf("%s%s", w.baseUrl, loginUrl)
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(form.Encode()))
 	if err != nil {
 		return false, err
 	}
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := w.httpClient.Do(req)
+	resp, err := w.RetryPolicy.withRetry(ctx, func() (*http.Response, error) {
+		// Rewind the form body before each attempt; it was fully read by
+		// the previous one.
+		if req.GetBody != nil {
+			body, bErr := req.GetBody()
+			if bErr != nil {
+				return nil, bErr
+			}
+			req.Body = body
+		}
+		return w.httpClient.Do(req)
+	})
 	if err != nil {
 		return false, err
 	}
@@ -79,11 +145,34 @@ func (w BookingClient) Login(username string, password string) (bool, error) {
 	return strings.HasPrefix(pageTitle, "Welcome"), nil
 }
 
+// GetCourseAvailability retrieves available tee times using
+// context.Background(). See GetCourseAvailabilityContext for the
+// context-aware variant.
 func (w BookingClient) GetCourseAvailability(dateStr string) ([]models.TimeSlot, error) {
-	slots := []models.TimeSlot{}
+	return w.GetCourseAvailabilityContext(context.Background(), dateStr)
+}
+
+// GetCourseAvailabilityContext retrieves available tee times for a given
+// date, bounding the request to ctx.
+func (w BookingClient) GetCourseAvailabilityContext(ctx context.Context, dateStr string) (slots []models.TimeSlot, err error) {
+	start := time.Now()
+	statusCode := 0
+	defer func() {
+		w.Metrics.AvailabilityFetch(dateStr, len(slots), time.Since(start), statusCode)
+		w.emit(Event{
+			Type:       EventAvailabilityFetch,
+			Course:     w.Course,
+			Date:       dateStr,
+			SlotCount:  len(slots),
+			StatusCode: statusCode,
+			Duration:   time.Since(start),
+		})
+	}()
+
+	slots = []models.TimeSlot{}
 
 	url := fmt.Sprintf("%s%s", w.baseUrl, teeAvailability)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return slots, err
 	}
@@ -92,12 +181,15 @@ func (w BookingClient) GetCourseAvailability(dateStr string) ([]models.TimeSlot,
 	q.Add("date", dateStr)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := w.httpClient.Do(req)
+	resp, err := w.RetryPolicy.withRetry(ctx, func() (*http.Response, error) {
+		return w.httpClient.Do(req)
+	})
 	if err != nil {
 		return slots, err
 	}
 
 	defer resp.Body.Close()
+	statusCode = resp.StatusCode
 
 	if resp.StatusCode != 200 {
 		return slots, fmt.Errorf("invalid status code returned %d", resp.StatusCode)
@@ -135,11 +227,39 @@ func (w BookingClient) GetCourseAvailability(dateStr string) ([]models.TimeSlot,
 	return slots, nil
 }
 
+// BookTimeSlot books the specified time slot using context.Background().
+// See BookTimeSlotContext for the context-aware variant.
 func (w BookingClient) BookTimeSlot(timeSlot models.TimeSlot, playingPartners []string, dryRun bool) (string, error) {
+	return w.BookTimeSlotContext(context.Background(), timeSlot, playingPartners, dryRun)
+}
+
+// BookTimeSlotContext books the specified time slot, bounding the request to
+// ctx so a stalled booking attempt can be abandoned in time to try another
+// slot.
+func (w BookingClient) BookTimeSlotContext(ctx context.Context, timeSlot models.TimeSlot, playingPartners []string, dryRun bool) (bookingID string, err error) {
+	start := time.Now()
+	outcome := OutcomeFailed
+	course := w.Course
+	if c := timeSlot.BookingForm["course"]; c != "" {
+		course = c
+	}
+	date := timeSlot.BookingForm["date"]
+	defer func() {
+		w.Metrics.BookingAttempt(course, date, timeSlot.Time, outcome, time.Since(start))
+		w.emit(Event{
+			Type:     EventBookingAttempt,
+			Course:   course,
+			Date:     date,
+			Time:     timeSlot.Time,
+			Outcome:  outcome,
+			Duration: time.Since(start),
+		})
+	}()
+
 	numSlots := len(playingPartners) + 1 // +1 for the main player
 
 	url := fmt.Sprintf("%s%s", w.baseUrl, book)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
@@ -159,11 +279,33 @@ func (w BookingClient) BookTimeSlot(timeSlot models.TimeSlot, playingPartners []
 	log.Printf("Calling %s", req.URL.String())
 	if dryRun {
 		log.Printf("DRY RUN: Would have booked time slot: %s for %d people", timeSlot.Time, numSlots)
+		outcome = OutcomeSuccess
 		return "dryrun-booking-id", nil
 	}
 
-	resp, err := w.httpClient.Do(req)
+	resp, err := w.RetryPolicy.withRetry(ctx, func() (*http.Response, error) {
+		wrote := false
+		traced := httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			WroteRequest: func(info httptrace.WroteRequestInfo) {
+				wrote = info.Err == nil
+			},
+		})
+
+		resp, doErr := w.httpClient.Do(req.WithContext(traced))
+		if doErr != nil && wrote {
+			// The request reached the wire before it failed, so the
+			// server may have processed it. Blindly retrying could
+			// double-book, so surface the ambiguity instead.
+			return nil, ErrBookingAmbiguous
+		}
+		return resp, doErr
+	})
 	if err != nil {
+		if errors.Is(err, ErrBookingAmbiguous) {
+			outcome = OutcomeAmbiguous
+		} else {
+			outcome = OutcomeNetworkError
+		}
 		return "", err
 	}
 
@@ -184,11 +326,12 @@ func (w BookingClient) BookTimeSlot(timeSlot models.TimeSlot, playingPartners []
 	}
 
 	// Extract booking ID from the current URL
-	bookingID, err := w.extractBookingID(resp.Request.URL.String())
+	bookingID, err = w.extractBookingID(resp.Request.URL.String())
 	if err != nil {
 		return "", fmt.Errorf("failed to extract booking ID: %v", err)
 	}
 
+	outcome = OutcomeSuccess
 	return bookingID, nil
 }
 
@@ -202,9 +345,24 @@ func (w BookingClient) extractBookingID(urlStr string) (string, error) {
 	return matches[1], nil
 }
 
+// AddPlayingPartner adds a playing partner to an existing booking using
+// context.Background(). See AddPlayingPartnerContext for the context-aware
+// variant.
 func (w BookingClient) AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	return w.AddPlayingPartnerContext(context.Background(), bookingID, partnerID, slotNumber, dryRun)
+}
+
+// AddPlayingPartnerContext adds a playing partner to an existing booking,
+// bounding the request to ctx.
+func (w BookingClient) AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error {
+	outcome := OutcomeFailed
+	defer func() {
+		w.Metrics.PartnerAdd(outcome)
+		w.emit(Event{Type: EventPartnerAdd, Course: w.Course, Outcome: outcome})
+	}()
+
 	url := fmt.Sprintf("%s%s", w.baseUrl, book)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
@@ -218,11 +376,15 @@ func (w BookingClient) AddPlayingPartner(bookingID, partnerID string, slotNumber
 	log.Printf("Adding partner: %s", req.URL.String())
 	if dryRun {
 		log.Printf("DRY RUN: Would have added partner %s to slot %d for booking %s", partnerID, slotNumber, bookingID)
+		outcome = OutcomeSuccess
 		return nil
 	}
 
-	resp, err := w.httpClient.Do(req)
+	resp, err := w.RetryPolicy.withRetry(ctx, func() (*http.Response, error) {
+		return w.httpClient.Do(req)
+	})
 	if err != nil {
+		outcome = OutcomeNetworkError
 		return err
 	}
 	defer resp.Body.Close()
@@ -231,5 +393,6 @@ func (w BookingClient) AddPlayingPartner(bookingID, partnerID string, slotNumber
 		return fmt.Errorf("failed to add partner: status code %d", resp.StatusCode)
 	}
 
+	outcome = OutcomeSuccess
 	return nil
 }
@@ -1,12 +1,14 @@
 package clients
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stebennett/tee-sniper/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -765,3 +767,101 @@ func TestAddBrowserHeadersSetsAllHeaders(t *testing.T) {
 func TestBookingClientImplementsBookingService(t *testing.T) {
 	var _ BookingService = (*BookingClient)(nil)
 }
+
+// ============================================================================
+// Context Variant Tests
+// ============================================================================
+
+func TestLoginContextSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(loadFixture(t, "login_success.html"))
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	success, err := client.LoginContext(context.Background(), "testuser", "testpin")
+
+	require.NoError(t, err)
+	assert.True(t, success)
+}
+
+func TestLoginContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.LoginContext(ctx, "testuser", "testpin")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetCourseAvailabilityContextDeadlineExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	_, err = client.GetCourseAvailabilityContext(ctx, "01-01-2024")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBookTimeSlotContextDryRunIgnoresCancellation(t *testing.T) {
+	client, err := NewBookingClient("https://example.com/")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bookingID, err := client.BookTimeSlotContext(ctx, models.TimeSlot{Time: "10:00"}, nil, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, "dryrun-booking-id", bookingID)
+}
+
+func TestAddPlayingPartnerContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = client.AddPlayingPartnerContext(ctx, "booking1", "partner1", 2, false)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNonContextMethodsDelegateToContextVariants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(loadFixture(t, "login_success.html"))
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	success, err := client.Login("testuser", "testpin")
+
+	require.NoError(t, err)
+	assert.True(t, success)
+}
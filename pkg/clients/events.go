@@ -0,0 +1,85 @@
+package clients
+
+import "time"
+
+// BookingOutcome classifies the result of a single booking or partner-add
+// attempt for metrics and event reporting.
+type BookingOutcome string
+
+const (
+	OutcomeSuccess      BookingOutcome = "success"
+	OutcomeFailed       BookingOutcome = "failed"
+	OutcomeNetworkError BookingOutcome = "network_error"
+	OutcomeAmbiguous    BookingOutcome = "ambiguous"
+)
+
+// Metrics receives structured observations from a BookingClient at each key
+// point in the booking workflow, so a long-running daemon can expose them
+// without scraping its own logs. See pkg/clients/metrics for a
+// prometheus.Collector-based implementation; NewBookingClient/
+// NewBookingClientWithOptions default to a no-op implementation.
+type Metrics interface {
+	// LoginAttempt records the outcome and wall-clock duration of a Login
+	// or LoginContext call.
+	LoginAttempt(success bool, duration time.Duration)
+
+	// AvailabilityFetch records a GetCourseAvailability(Context) call: how
+	// many bookable slots it found, how long it took, and the HTTP status
+	// returned by the booking site.
+	AvailabilityFetch(date string, slotCount int, duration time.Duration, statusCode int)
+
+	// BookingAttempt records a BookTimeSlot(Context) call for the given
+	// course, date, and time.
+	BookingAttempt(course, date, time string, outcome BookingOutcome, duration time.Duration)
+
+	// PartnerAdd records the outcome of an AddPlayingPartner(Context) call.
+	PartnerAdd(outcome BookingOutcome)
+}
+
+// noopMetrics discards every observation. It is the default Metrics on a new
+// BookingClient, so call sites never need a nil check before recording one.
+type noopMetrics struct{}
+
+func (noopMetrics) LoginAttempt(success bool, duration time.Duration) {}
+func (noopMetrics) AvailabilityFetch(date string, slotCount int, duration time.Duration, statusCode int) {
+}
+func (noopMetrics) BookingAttempt(course, date, time string, outcome BookingOutcome, duration time.Duration) {
+}
+func (noopMetrics) PartnerAdd(outcome BookingOutcome) {}
+
+// EventType identifies the kind of observation carried by an Event.
+type EventType string
+
+const (
+	EventLoginAttempt      EventType = "login_attempt"
+	EventAvailabilityFetch EventType = "availability_fetch"
+	EventBookingAttempt    EventType = "booking_attempt"
+	EventPartnerAdd        EventType = "partner_add"
+)
+
+// Event is the lighter-weight counterpart to Metrics: every observation a
+// BookingClient makes is also passed to EventHook (if set), so callers who
+// don't want Prometheus can pipe events into their own logger or webhook
+// without implementing the full Metrics interface.
+type Event struct {
+	Type       EventType
+	Course     string
+	Date       string
+	Time       string
+	Success    bool
+	SlotCount  int
+	StatusCode int
+	Outcome    BookingOutcome
+	Duration   time.Duration
+}
+
+// EventHook, if set on a BookingClient, is called with every Event the
+// client records, alongside whatever Metrics is configured.
+type EventHook func(Event)
+
+// emit fires EventHook (if set) for a single observation.
+func (w BookingClient) emit(e Event) {
+	if w.EventHook != nil {
+		w.EventHook(e)
+	}
+}
@@ -0,0 +1,191 @@
+package clients
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMetrics captures every call made through the Metrics interface,
+// for asserting that BookingClient invoked it with the expected arguments.
+type recordingMetrics struct {
+	loginAttempts       []bool
+	availabilityFetches []int
+	bookingAttempts     []BookingOutcome
+	partnerAdds         []BookingOutcome
+}
+
+func (r *recordingMetrics) LoginAttempt(success bool, duration time.Duration) {
+	r.loginAttempts = append(r.loginAttempts, success)
+}
+
+func (r *recordingMetrics) AvailabilityFetch(date string, slotCount int, duration time.Duration, statusCode int) {
+	r.availabilityFetches = append(r.availabilityFetches, statusCode)
+}
+
+func (r *recordingMetrics) BookingAttempt(course, date, timeStr string, outcome BookingOutcome, duration time.Duration) {
+	r.bookingAttempts = append(r.bookingAttempts, outcome)
+}
+
+func (r *recordingMetrics) PartnerAdd(outcome BookingOutcome) {
+	r.partnerAdds = append(r.partnerAdds, outcome)
+}
+
+func TestLoginContextRecordsMetricsAndEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("<html><head><title>Welcome</title></head></html>"))
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	metrics := &recordingMetrics{}
+	client.Metrics = metrics
+
+	var gotEvent Event
+	client.EventHook = func(e Event) { gotEvent = e }
+
+	success, err := client.Login("user", "pin")
+	require.NoError(t, err)
+	assert.True(t, success)
+
+	require.Len(t, metrics.loginAttempts, 1)
+	assert.True(t, metrics.loginAttempts[0])
+
+	assert.Equal(t, EventLoginAttempt, gotEvent.Type)
+	assert.True(t, gotEvent.Success)
+}
+
+func TestGetCourseAvailabilityContextRecordsMetricsAndEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("<html><body><table></table></body></html>"))
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	metrics := &recordingMetrics{}
+	client.Metrics = metrics
+
+	var gotEvent Event
+	client.EventHook = func(e Event) { gotEvent = e }
+
+	_, err = client.GetCourseAvailability("2024-01-15")
+	require.NoError(t, err)
+
+	require.Len(t, metrics.availabilityFetches, 1)
+	assert.Equal(t, http.StatusOK, metrics.availabilityFetches[0])
+
+	assert.Equal(t, EventAvailabilityFetch, gotEvent.Type)
+	assert.Equal(t, "2024-01-15", gotEvent.Date)
+	assert.Equal(t, http.StatusOK, gotEvent.StatusCode)
+}
+
+func TestBookTimeSlotContextRecordsAmbiguousOutcome(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		require.True(t, ok)
+		conn, _, err := hj.Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+	client.RetryPolicy = RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxAttempts: 1}
+
+	metrics := &recordingMetrics{}
+	client.Metrics = metrics
+
+	var gotEvent Event
+	client.EventHook = func(e Event) { gotEvent = e }
+
+	timeSlot := models.TimeSlot{
+		Time:        "09:00",
+		BookingForm: map[string]string{"date": "2024-01-15", "course": "1"},
+	}
+
+	_, err = client.BookTimeSlot(timeSlot, nil, false)
+	require.Error(t, err)
+
+	require.Len(t, metrics.bookingAttempts, 1)
+	assert.Equal(t, OutcomeAmbiguous, metrics.bookingAttempts[0])
+	assert.Equal(t, OutcomeAmbiguous, gotEvent.Outcome)
+	assert.Equal(t, "1", gotEvent.Course)
+}
+
+func TestBookTimeSlotContextRecordsSuccessOutcome(t *testing.T) {
+	successHTML := `<html><body><div id="globalwrap">` +
+		`<div class="user-messages alert user-message-success alert-success">` +
+		`<ul><li><strong>Now please enter the names of your playing partners.</strong></li></ul>` +
+		`</div></div></body></html>`
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.RawQuery, "edit=") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(successHTML))
+		} else {
+			http.Redirect(w, r, "/memberbooking/?edit=BOOK123", http.StatusFound)
+		}
+	}))
+	defer redirectServer.Close()
+
+	client, err := NewBookingClient(redirectServer.URL + "/")
+	require.NoError(t, err)
+
+	metrics := &recordingMetrics{}
+	client.Metrics = metrics
+
+	timeSlot := models.TimeSlot{
+		Time:        "09:00",
+		BookingForm: map[string]string{"date": "2024-01-15", "course": "1"},
+	}
+
+	bookingID, err := client.BookTimeSlot(timeSlot, []string{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "BOOK123", bookingID)
+
+	require.Len(t, metrics.bookingAttempts, 1)
+	assert.Equal(t, OutcomeSuccess, metrics.bookingAttempts[0])
+}
+
+func TestAddPlayingPartnerContextRecordsMetricsAndEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	metrics := &recordingMetrics{}
+	client.Metrics = metrics
+
+	var gotEvent Event
+	client.EventHook = func(e Event) { gotEvent = e }
+
+	err = client.AddPlayingPartner("BOOK123", "partner1", 2, false)
+	require.NoError(t, err)
+
+	require.Len(t, metrics.partnerAdds, 1)
+	assert.Equal(t, OutcomeSuccess, metrics.partnerAdds[0])
+	assert.Equal(t, EventPartnerAdd, gotEvent.Type)
+	assert.Equal(t, OutcomeSuccess, gotEvent.Outcome)
+}
+
+func TestNewBookingClientDefaultsToNoopMetrics(t *testing.T) {
+	client, err := NewBookingClient("https://example.com/")
+	require.NoError(t, err)
+	assert.NotNil(t, client.Metrics)
+}
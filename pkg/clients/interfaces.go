@@ -2,11 +2,19 @@ package clients
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock_clients.go -package=mocks
 
-import "github.com/stebennett/tee-sniper/pkg/models"
+import (
+	"context"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+)
 
 // Compile-time verification that concrete types implement interfaces
 var _ BookingService = (*BookingClient)(nil)
 var _ SMSService = (*TwilioClient)(nil)
+var _ VoiceService = (*TwilioVoiceClient)(nil)
+var _ Notifier = (*SMSNotifier)(nil)
+var _ Notifier = (*NtfyClient)(nil)
+var _ Notifier = (*RateLimitedNotifier)(nil)
 
 // BookingService defines the interface for booking operations.
 // This interface is implemented by BookingClient and can be mocked for testing.
@@ -15,22 +23,73 @@ type BookingService interface {
 	// Returns true if login was successful, false otherwise.
 	Login(username, password string) (bool, error)
 
+	// LoginContext is the context-aware variant of Login. Callers that need
+	// to bound or cancel a login attempt (e.g. from a scheduler) should
+	// prefer this over Login.
+	LoginContext(ctx context.Context, username, password string) (bool, error)
+
 	// GetCourseAvailability retrieves available tee times for a given date.
 	// The dateStr should be in the format expected by the booking site.
 	GetCourseAvailability(dateStr string) ([]models.TimeSlot, error)
 
+	// GetCourseAvailabilityContext is the context-aware variant of
+	// GetCourseAvailability.
+	GetCourseAvailabilityContext(ctx context.Context, dateStr string) ([]models.TimeSlot, error)
+
 	// BookTimeSlot books the specified time slot.
 	// Returns the booking ID on success.
 	BookTimeSlot(timeSlot models.TimeSlot, playingPartners []string, dryRun bool) (string, error)
 
+	// BookTimeSlotContext is the context-aware variant of BookTimeSlot.
+	BookTimeSlotContext(ctx context.Context, timeSlot models.TimeSlot, playingPartners []string, dryRun bool) (string, error)
+
 	// AddPlayingPartner adds a playing partner to an existing booking.
 	AddPlayingPartner(bookingID, partnerID string, slotNumber int, dryRun bool) error
+
+	// AddPlayingPartnerContext is the context-aware variant of AddPlayingPartner.
+	AddPlayingPartnerContext(ctx context.Context, bookingID, partnerID string, slotNumber int, dryRun bool) error
 }
 
 // SMSService defines the interface for SMS operations.
 // This interface is implemented by TwilioClient and can be mocked for testing.
 type SMSService interface {
-	// SendSms sends an SMS message.
+	// SendSms sends an SMS message using context.Background().
 	// In dry run mode, the message is logged but not actually sent.
 	SendSms(from, to, body string, dryRun bool) error
+
+	// SendSmsWithContext is the context-aware variant of SendSms. Callers
+	// that need to bound or cancel a send (e.g. from a scheduler) should
+	// prefer this over SendSms.
+	SendSmsWithContext(ctx context.Context, from, to, body string, dryRun bool) error
+}
+
+// VoiceService defines the interface for placing phone calls.
+// This interface is implemented by TwilioVoiceClient and can be mocked for testing.
+type VoiceService interface {
+	// PlaceCall places a phone call from `from` to `to`, executing the given
+	// inline TwiML or fetching it from the given URL. In dry run mode, the
+	// call is logged but not actually placed.
+	PlaceCall(from, to, twimlOrURL string, dryRun bool) error
+}
+
+// Priority indicates how urgently a notification should be delivered.
+// Backends that don't support priority levels (e.g. plain SMS) ignore it.
+type Priority int
+
+const (
+	PriorityDefault Priority = iota
+	PriorityLow
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Notifier generalizes SMSService into a broader notification contract so
+// the booking flow can fan out across any mix of backends (SMS, ntfy push,
+// and in future Slack/Discord/Pushover) without changing call sites.
+// Implemented by SMSNotifier (wrapping an SMSService) and NtfyClient, and
+// can be mocked for testing.
+type Notifier interface {
+	// Notify sends subject/body to recipient. In dry run mode the
+	// notification is logged but not actually delivered.
+	Notify(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error
 }
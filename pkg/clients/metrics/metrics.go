@@ -0,0 +1,151 @@
+// Package metrics provides a Prometheus-backed implementation of
+// clients.Metrics, so a long-running tee-sniper daemon can expose what it's
+// doing to a scraper instead of relying on logs alone.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+// PrometheusMetrics implements clients.Metrics on top of a set of
+// prometheus.Collector instances. It also implements prometheus.Collector
+// itself, so a single call to registry.MustRegister(m) registers everything.
+type PrometheusMetrics struct {
+	loginAttempts       *prometheus.CounterVec
+	loginDuration       prometheus.Histogram
+	availabilityFetches *prometheus.CounterVec
+	availabilitySlots   prometheus.Histogram
+	availabilityLatency prometheus.Histogram
+	bookingAttempts     *prometheus.CounterVec
+	bookingDuration     *prometheus.HistogramVec
+	partnerAdds         *prometheus.CounterVec
+	lastSuccessfulScan  *prometheus.GaugeVec
+}
+
+// New creates a PrometheusMetrics with all collectors initialized under the
+// given namespace (e.g. "tee_sniper"). Register the result with a
+// prometheus.Registerer before use.
+func New(namespace string) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		loginAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "login_attempts_total",
+			Help:      "Total number of login attempts, labeled by outcome.",
+		}, []string{"success"}),
+		loginDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "login_duration_seconds",
+			Help:      "Duration of login attempts in seconds.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		availabilityFetches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "availability_fetches_total",
+			Help:      "Total number of availability fetches, labeled by HTTP status code.",
+		}, []string{"status_code"}),
+		availabilitySlots: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "availability_slots_found",
+			Help:      "Number of bookable slots returned by an availability fetch.",
+			Buckets:   []float64{0, 1, 2, 4, 8, 16, 32},
+		}),
+		availabilityLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "availability_duration_seconds",
+			Help:      "Duration of availability fetches in seconds.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		bookingAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "attempts_total",
+			Help:      "Total number of booking attempts, labeled by course and outcome.",
+		}, []string{"course", "outcome"}),
+		bookingDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "attempt_duration_seconds",
+			Help:      "Duration of booking attempts in seconds, labeled by course and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"course", "outcome"}),
+		partnerAdds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "partner_adds_total",
+			Help:      "Total number of playing-partner adds, labeled by outcome.",
+		}, []string{"outcome"}),
+		lastSuccessfulScan: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "booking",
+			Name:      "last_successful_scan_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful booking attempt, labeled by course. Availability fetches do not update it.",
+		}, []string{"course"}),
+	}
+}
+
+// LoginAttempt implements clients.Metrics.
+func (m *PrometheusMetrics) LoginAttempt(success bool, duration time.Duration) {
+	m.loginAttempts.WithLabelValues(strconv.FormatBool(success)).Inc()
+	m.loginDuration.Observe(duration.Seconds())
+}
+
+// AvailabilityFetch implements clients.Metrics.
+func (m *PrometheusMetrics) AvailabilityFetch(date string, slotCount int, duration time.Duration, statusCode int) {
+	m.availabilityFetches.WithLabelValues(strconv.Itoa(statusCode)).Inc()
+	m.availabilitySlots.Observe(float64(slotCount))
+	m.availabilityLatency.Observe(duration.Seconds())
+}
+
+// BookingAttempt implements clients.Metrics.
+func (m *PrometheusMetrics) BookingAttempt(course, date, timeStr string, outcome clients.BookingOutcome, duration time.Duration) {
+	m.bookingAttempts.WithLabelValues(course, string(outcome)).Inc()
+	m.bookingDuration.WithLabelValues(course, string(outcome)).Observe(duration.Seconds())
+	if outcome == clients.OutcomeSuccess {
+		m.lastSuccessfulScan.WithLabelValues(course).SetToCurrentTime()
+	}
+}
+
+// PartnerAdd implements clients.Metrics.
+func (m *PrometheusMetrics) PartnerAdd(outcome clients.BookingOutcome) {
+	m.partnerAdds.WithLabelValues(string(outcome)).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *PrometheusMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.loginAttempts,
+		m.loginDuration,
+		m.availabilityFetches,
+		m.availabilitySlots,
+		m.availabilityLatency,
+		m.bookingAttempts,
+		m.bookingDuration,
+		m.partnerAdds,
+		m.lastSuccessfulScan,
+	}
+}
+
+var _ clients.Metrics = (*PrometheusMetrics)(nil)
+var _ prometheus.Collector = (*PrometheusMetrics)(nil)
@@ -0,0 +1,77 @@
+package clients
+
+import (
+	"log"
+	"strings"
+)
+
+// NotifierConfig describes which Notifier backend(s) to build and how to
+// configure each one. It mirrors the relevant fields of config.Config so
+// any cmd/ entrypoint (tee-sniper, booker) can build the same fan-out
+// without depending on the monolith's full Config.
+type NotifierConfig struct {
+	// Notifier selects which backend(s) to build: "twilio", "ntfy", or
+	// "both". Defaults to "twilio".
+	Notifier     string
+	FromNumber   string
+	NtfyTopicURL string
+
+	VerifyServiceSid      string
+	VerificationStorePath string
+
+	DailyLimitPerRecipient int
+	RateLimitStorePath     string
+}
+
+// NotifiersFromConfig builds the Notifier fan-out list selected by
+// cfg.Notifier ("twilio", "ntfy", or "both"), with each backend wrapped in
+// a RateLimitedNotifier sharing a single on-disk RateLimiter so the
+// recipient's daily cap is enforced across every backend, not per-backend.
+func NotifiersFromConfig(cfg NotifierConfig) []Notifier {
+	var notifiers []Notifier
+
+	limiter, err := NewRateLimiter(cfg.RateLimitStorePath, cfg.DailyLimitPerRecipient)
+	if err != nil {
+		log.Printf("Failed to load rate limit store, notifications will be unlimited: %s", err.Error())
+	}
+
+	switch strings.ToLower(cfg.Notifier) {
+	case "ntfy":
+		notifiers = append(notifiers, NewNtfyClient(cfg.NtfyTopicURL))
+	case "both":
+		notifiers = append(notifiers, NewSMSNotifier(TwilioClientFromConfig(cfg), cfg.FromNumber))
+		notifiers = append(notifiers, NewNtfyClient(cfg.NtfyTopicURL))
+	default:
+		notifiers = append(notifiers, NewSMSNotifier(TwilioClientFromConfig(cfg), cfg.FromNumber))
+	}
+
+	if limiter == nil {
+		return notifiers
+	}
+
+	for i, n := range notifiers {
+		notifiers[i] = NewRateLimitedNotifier(n, limiter)
+	}
+
+	return notifiers
+}
+
+// TwilioClientFromConfig builds a TwilioClient, gated on opt-in
+// verification when cfg.VerifyServiceSid is set so a misconfigured
+// recipient can't be spammed with booking alerts before completing
+// `tee-sniper verify`.
+func TwilioClientFromConfig(cfg NotifierConfig) *TwilioClient {
+	client := NewTwilioClient()
+	if cfg.VerifyServiceSid == "" {
+		return client
+	}
+
+	store, err := NewVerificationStore(cfg.VerificationStorePath)
+	if err != nil {
+		log.Printf("Failed to load verification store, numbers will be treated as unverified: %s", err.Error())
+		return client
+	}
+	client.VerificationStore = store
+
+	return client
+}
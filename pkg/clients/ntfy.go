@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// ntfyPriority maps Priority to the header values ntfy.sh understands.
+var ntfyPriority = map[Priority]string{
+	PriorityLow:     "low",
+	PriorityDefault: "default",
+	PriorityHigh:    "high",
+	PriorityUrgent:  "urgent",
+}
+
+// NtfyClient sends push notifications via ntfy.sh (or a self-hosted ntfy
+// instance), POSTing the message body to a topic URL with the headers ntfy
+// uses for title, priority, tags, and a deep link.
+type NtfyClient struct {
+	httpClient *http.Client
+	topicURL   string
+
+	// Tags is sent as the ntfy "Tags" header: a comma-separated list of
+	// ntfy emoji short codes, e.g. "golf,white_check_mark".
+	Tags string
+
+	// ClickURL, if set, is sent as the ntfy "Click" header so tapping the
+	// notification deep-links into the booking page.
+	ClickURL string
+}
+
+// NewNtfyClient creates an NtfyClient that publishes to topicURL, e.g.
+// "https://ntfy.sh/my-tee-sniper-topic".
+func NewNtfyClient(topicURL string) *NtfyClient {
+	return &NtfyClient{
+		httpClient: &http.Client{},
+		topicURL:   topicURL,
+	}
+}
+
+// Notify implements Notifier. recipient is unused: an ntfy topic has no
+// per-recipient addressing, so notifying multiple people means configuring
+// one NtfyClient per topic they're subscribed to.
+func (n NtfyClient) Notify(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error {
+	if dryRun {
+		log.Printf("DRY RUN: Would have sent ntfy notification to %s: %s: %s", n.topicURL, subject, body)
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.topicURL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	if subject != "" {
+		req.Header.Set("Title", subject)
+	}
+	req.Header.Set("Priority", ntfyPriority[priority])
+	if n.Tags != "" {
+		req.Header.Set("Tags", n.Tags)
+	}
+	if n.ClickURL != "" {
+		req.Header.Set("Click", n.ClickURL)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ntfy: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
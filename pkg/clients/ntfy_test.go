@@ -0,0 +1,96 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewNtfyClient tests that the constructor creates a valid client
+func TestNewNtfyClient(t *testing.T) {
+	client := NewNtfyClient("https://ntfy.sh/my-topic")
+
+	require.NotNil(t, client)
+	assert.Equal(t, "https://ntfy.sh/my-topic", client.topicURL)
+}
+
+// TestNtfyClientNotifySendsHeadersAndBody tests that title, priority and body reach the server
+func TestNtfyClientNotifySendsHeadersAndBody(t *testing.T) {
+	var gotTitle, gotPriority, gotTags, gotClick, gotBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTitle = r.Header.Get("Title")
+		gotPriority = r.Header.Get("Priority")
+		gotTags = r.Header.Get("Tags")
+		gotClick = r.Header.Get("Click")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewNtfyClient(server.URL)
+	client.Tags = "golf,white_check_mark"
+	client.ClickURL = "https://example.com/bookings/123"
+
+	err := client.Notify(context.Background(), "unused", "Tee time booked", "10:00 on 22-01-2024", PriorityHigh, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Tee time booked", gotTitle)
+	assert.Equal(t, "high", gotPriority)
+	assert.Equal(t, "golf,white_check_mark", gotTags)
+	assert.Equal(t, "https://example.com/bookings/123", gotClick)
+	assert.Equal(t, "10:00 on 22-01-2024", gotBody)
+}
+
+// TestNtfyClientNotifyDryRun tests that dry run mode doesn't make a network request
+func TestNtfyClientNotifyDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be made in dry run mode")
+	}))
+	defer server.Close()
+
+	client := NewNtfyClient(server.URL)
+
+	err := client.Notify(context.Background(), "unused", "Subject", "Body", PriorityDefault, true)
+
+	assert.NoError(t, err)
+}
+
+// TestNtfyClientNotifyNonOKStatus tests that a non-200 response is surfaced as an error
+func TestNtfyClientNotifyNonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewNtfyClient(server.URL)
+
+	err := client.Notify(context.Background(), "unused", "Subject", "Body", PriorityDefault, false)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+// TestNtfyClientNotifyWithoutSubject tests that an empty subject omits the Title header
+func TestNtfyClientNotifyWithoutSubject(t *testing.T) {
+	var titleHeaderPresent bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, titleHeaderPresent = r.Header["Title"]
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewNtfyClient(server.URL)
+
+	err := client.Notify(context.Background(), "unused", "", "Body", PriorityDefault, false)
+
+	assert.NoError(t, err)
+	assert.False(t, titleHeaderPresent)
+}
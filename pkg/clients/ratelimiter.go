@@ -0,0 +1,150 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by RateLimiter.Allow, and by any Notifier
+// wrapped in a RateLimitedNotifier, once a recipient has hit its rolling
+// 24h notification limit.
+var ErrRateLimited = errors.New("notification rate limit exceeded for recipient")
+
+// rateLimitWindow is the rolling window RateLimiter counts notifications over.
+const rateLimitWindow = 24 * time.Hour
+
+// RateLimiter tracks how many notifications each recipient has received in
+// the trailing 24h and refuses further sends past dailyLimit, persisting its
+// state to disk so a process restart doesn't reset the count - otherwise a
+// flaky scraper that keeps restarting could still run up a large Twilio
+// bill one notification at a time.
+type RateLimiter struct {
+	mu         sync.Mutex
+	path       string
+	dailyLimit int
+	sent       map[string][]time.Time
+}
+
+// NewRateLimiter creates a RateLimiter backed by path, allowing up to
+// dailyLimit notifications per recipient per rolling 24h window. Any
+// notifications already persisted at path are loaded.
+func NewRateLimiter(path string, dailyLimit int) (*RateLimiter, error) {
+	r := &RateLimiter{
+		path:       path,
+		dailyLimit: dailyLimit,
+		sent:       make(map[string][]time.Time),
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Allow reports whether recipient may be sent another notification right
+// now. If so, the attempt is recorded and persisted; otherwise
+// ErrRateLimited is returned and nothing is recorded.
+func (r *RateLimiter) Allow(recipient string) error {
+	r.mu.Lock()
+	now := time.Now()
+	recent := pruneBefore(r.sent[recipient], now.Add(-rateLimitWindow))
+
+	if len(recent) >= r.dailyLimit {
+		r.sent[recipient] = recent
+		r.mu.Unlock()
+		return ErrRateLimited
+	}
+
+	r.sent[recipient] = append(recent, now)
+	r.mu.Unlock()
+
+	return r.save()
+}
+
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	pruned := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) {
+			pruned = append(pruned, t)
+		}
+	}
+	return pruned
+}
+
+func (r *RateLimiter) load() error {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored map[string][]time.Time
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	r.sent = stored
+	return nil
+}
+
+func (r *RateLimiter) save() error {
+	r.mu.Lock()
+	data, err := json.Marshal(r.sent)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0600)
+}
+
+// rateLimitBypassKey is the context key RateLimitedNotifier checks to skip
+// the rate limit entirely, for notifications (like a successful booking
+// confirmation) that must always be delivered.
+type rateLimitBypassKey struct{}
+
+// WithRateLimitBypass returns a context that causes a RateLimitedNotifier to
+// skip its rate limit check for this call.
+func WithRateLimitBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateLimitBypassKey{}, true)
+}
+
+func rateLimitBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(rateLimitBypassKey{}).(bool)
+	return bypass
+}
+
+// RateLimitedNotifier wraps a Notifier so it refuses to deliver more than a
+// configured number of notifications per recipient per rolling 24h window,
+// unless the call's context was built with WithRateLimitBypass.
+type RateLimitedNotifier struct {
+	notifier Notifier
+	limiter  *RateLimiter
+}
+
+// NewRateLimitedNotifier wraps notifier with limiter.
+func NewRateLimitedNotifier(notifier Notifier, limiter *RateLimiter) *RateLimitedNotifier {
+	return &RateLimitedNotifier{notifier: notifier, limiter: limiter}
+}
+
+// Notify implements Notifier. A dry run never calls Allow, since it never
+// actually sends anything - counting it against recipient's quota would
+// let a dry run permanently burn down the real daily limit.
+func (n RateLimitedNotifier) Notify(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error {
+	if !dryRun && !rateLimitBypassed(ctx) {
+		if err := n.limiter.Allow(recipient); err != nil {
+			return err
+		}
+	}
+
+	return n.notifier.Notify(ctx, recipient, subject, body, priority, dryRun)
+}
+
+var _ Notifier = (*RateLimitedNotifier)(nil)
@@ -0,0 +1,186 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotifier is a test double for Notifier
+type fakeNotifier struct {
+	notifyFunc func(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error
+	callCount  int
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error {
+	f.callCount++
+	if f.notifyFunc != nil {
+		return f.notifyFunc(ctx, recipient, subject, body, priority, dryRun)
+	}
+	return nil
+}
+
+// TestRateLimiterAllowsUnderLimit tests that requests under the daily limit succeed
+func TestRateLimiterAllowsUnderLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+
+	limiter, err := NewRateLimiter(path, 3)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Allow("+1234567890"))
+	}
+}
+
+// TestRateLimiterBlocksOverLimit tests that a recipient is refused past the daily limit
+func TestRateLimiterBlocksOverLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+
+	limiter, err := NewRateLimiter(path, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, limiter.Allow("+1234567890"))
+	require.NoError(t, limiter.Allow("+1234567890"))
+
+	assert.ErrorIs(t, limiter.Allow("+1234567890"), ErrRateLimited)
+}
+
+// TestRateLimiterTracksRecipientsIndependently tests that one recipient's usage
+// doesn't count against another
+func TestRateLimiterTracksRecipientsIndependently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+
+	limiter, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, limiter.Allow("+1234567890"))
+	assert.ErrorIs(t, limiter.Allow("+1234567890"), ErrRateLimited)
+	assert.NoError(t, limiter.Allow("+0987654321"))
+}
+
+// TestRateLimiterPersistsAcrossInstances tests that recorded sends survive a reload
+func TestRateLimiterPersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+
+	limiter, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+	require.NoError(t, limiter.Allow("+1234567890"))
+
+	reloaded, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, reloaded.Allow("+1234567890"), ErrRateLimited)
+}
+
+// TestRateLimiterLoadsMissingFile tests that a missing store file isn't an error
+func TestRateLimiterLoadsMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	limiter, err := NewRateLimiter(path, 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, limiter.Allow("+1234567890"))
+}
+
+// TestRateLimitedNotifierDelegatesWhenAllowed tests that the inner Notifier is
+// called when the recipient is under its limit
+func TestRateLimitedNotifierDelegatesWhenAllowed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+	limiter, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+
+	inner := &fakeNotifier{}
+	notifier := NewRateLimitedNotifier(inner, limiter)
+
+	err = notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inner.callCount)
+}
+
+// TestRateLimitedNotifierBlocksWithoutDelegating tests that the inner Notifier
+// is not called once the recipient has hit its limit
+func TestRateLimitedNotifierBlocksWithoutDelegating(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+	limiter, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+
+	inner := &fakeNotifier{
+		notifyFunc: func(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error {
+			t.Error("Notify should not be called once rate limited")
+			return nil
+		},
+	}
+	notifier := NewRateLimitedNotifier(inner, limiter)
+
+	require.NoError(t, notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false))
+
+	err = notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false)
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 0, inner.callCount)
+}
+
+// TestRateLimitedNotifierBypass tests that WithRateLimitBypass skips the limit
+// check entirely and still delegates to the inner Notifier
+func TestRateLimitedNotifierBypass(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+	limiter, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+
+	inner := &fakeNotifier{}
+	notifier := NewRateLimitedNotifier(inner, limiter)
+
+	require.NoError(t, notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false))
+
+	ctx := WithRateLimitBypass(context.Background())
+	err = notifier.Notify(ctx, "+0987654321", "Subject", "Body", PriorityDefault, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inner.callCount)
+}
+
+// TestRateLimitedNotifierDryRunDoesNotConsumeQuota tests that a dry run
+// neither hits the limit nor counts against a recipient's real quota.
+func TestRateLimitedNotifierDryRunDoesNotConsumeQuota(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+	limiter, err := NewRateLimiter(path, 1)
+	require.NoError(t, err)
+
+	inner := &fakeNotifier{}
+	notifier := NewRateLimitedNotifier(inner, limiter)
+
+	for i := 0; i < 3; i++ {
+		err = notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, true)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, inner.callCount)
+
+	err = notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, inner.callCount)
+}
+
+// TestRateLimitedNotifierPropagatesInnerError tests that errors from the
+// wrapped Notifier are returned unchanged
+func TestRateLimitedNotifierPropagatesInnerError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limit_store.json")
+	limiter, err := NewRateLimiter(path, 5)
+	require.NoError(t, err)
+
+	inner := &fakeNotifier{
+		notifyFunc: func(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error {
+			return errors.New("backend unavailable")
+		},
+	}
+	notifier := NewRateLimitedNotifier(inner, limiter)
+
+	err = notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "backend unavailable")
+}
@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrBookingAmbiguous is returned by BookTimeSlotContext when a request may
+// have reached the server before the failure occurred. In that case the
+// booking outcome is unknown, so it is not safe to blindly retry; the caller
+// should verify the true state with GetCourseAvailability first.
+var ErrBookingAmbiguous = errors.New("booking outcome is ambiguous: request may have reached the server before it failed")
+
+// RetryPolicy configures the exponential backoff with full jitter used when
+// Login, GetCourseAvailability, BookTimeSlot, and AddPlayingPartner hit a
+// network error or a 502/503/504 response.
+type RetryPolicy struct {
+	// BaseDelay is the starting delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff regardless of attempt count.
+	MaxDelay time.Duration
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+}
+
+// DefaultRetryPolicy returns sane defaults for a flaky tee-sheet site: up to
+// 5 attempts, starting at 200ms and capping at 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		MaxAttempts: 5,
+	}
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns a full-jitter delay for the given zero-indexed attempt:
+// a random value in [0, min(maxDelay, baseDelay*2^attempt)].
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	defaults := DefaultRetryPolicy()
+
+	base := p.BaseDelay
+	if base <= 0 {
+		base = defaults.BaseDelay
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = defaults.MaxDelay
+	}
+
+	capped := base << uint(attempt)
+	if capped <= 0 || capped > max {
+		capped = max
+	}
+
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+// isRetryableStatus reports whether a response status indicates a transient
+// failure worth retrying.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+// retryAfterDelay parses a Retry-After header, which may be either
+// delta-seconds or an HTTP-date, returning the duration to wait.
+func retryAfterDelay(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx is
+// cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// withRetry runs attempt up to policy.maxAttempts() times, retrying on
+// network errors and 502/503/504 responses with full-jitter exponential
+// backoff. A Retry-After header on the response overrides the computed
+// backoff for that iteration.
+func (p RetryPolicy) withRetry(ctx context.Context, attempt func() (*http.Response, error)) (*http.Response, error) {
+	var lastErr error
+
+	for i := 0; i < p.maxAttempts(); i++ {
+		resp, err := attempt()
+		if errors.Is(err, ErrBookingAmbiguous) {
+			return resp, err
+		}
+
+		retryable := err != nil || (resp != nil && isRetryableStatus(resp.StatusCode))
+		if !retryable {
+			return resp, err
+		}
+
+		lastErr = err
+		if i == p.maxAttempts()-1 {
+			return resp, err
+		}
+
+		delay := p.backoff(i)
+		if resp != nil {
+			if d, ok := retryAfterDelay(resp.Header); ok {
+				delay = d
+			}
+			resp.Body.Close()
+		}
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,185 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// RetryPolicy Tests
+// ============================================================================
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	assert.Equal(t, 200*time.Millisecond, policy.BaseDelay)
+	assert.Equal(t, 10*time.Second, policy.MaxDelay)
+	assert.Equal(t, 5, policy.MaxAttempts)
+}
+
+func TestBackoffStaysWithinBounds(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond, MaxAttempts: 10}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		for i := 0; i < 20; i++ {
+			delay := policy.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, policy.MaxDelay)
+		}
+	}
+}
+
+func TestRetryAfterDeltaSeconds(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "5")
+
+	d, ok := retryAfterDelay(h)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC().Truncate(time.Second)
+
+	h := http.Header{}
+	h.Set("Retry-After", future.Format(http.TimeFormat))
+
+	d, ok := retryAfterDelay(h)
+	require.True(t, ok)
+	assert.InDelta(t, 10*time.Second, d, float64(2*time.Second))
+}
+
+func TestRetryAfterMissing(t *testing.T) {
+	_, ok := retryAfterDelay(http.Header{})
+	assert.False(t, ok)
+}
+
+func TestWithRetryRetriesOn503(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+	client.RetryPolicy = RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxAttempts: 5}
+
+	success, err := client.LoginContext(context.Background(), "user", "pin")
+
+	require.NoError(t, err)
+	assert.False(t, success) // 200 with no "Welcome" title
+	assert.Equal(t, int32(3), attempts)
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+	client.RetryPolicy = RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxAttempts: 3}
+
+	_, err = client.GetCourseAvailabilityContext(context.Background(), "01-01-2024")
+
+	require.Error(t, err)
+	assert.Equal(t, int32(3), attempts)
+}
+
+func TestWithRetryHonorsRetryAfter(t *testing.T) {
+	var attempts int32
+	var firstAttemptAt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n == 1 {
+			firstAttemptAt = time.Now()
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+	client.RetryPolicy = RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, MaxAttempts: 3}
+
+	start := time.Now()
+	_, err = client.GetCourseAvailabilityContext(context.Background(), "01-01-2024")
+	require.NoError(t, err)
+
+	// A 0-second Retry-After should be honored instead of the 1s BaseDelay.
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, firstAttemptAt.IsZero())
+}
+
+func TestWithRetryNotRetryableOn404(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	_, err = client.GetCourseAvailabilityContext(context.Background(), "01-01-2024")
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), attempts)
+}
+
+// ============================================================================
+// BookTimeSlot Ambiguity Tests
+// ============================================================================
+
+func TestBookTimeSlotContextRetriesNetworkErrorBeforeSend(t *testing.T) {
+	client, err := NewBookingClient("http://127.0.0.1:0/")
+	require.NoError(t, err)
+	client.RetryPolicy = RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxAttempts: 2}
+
+	_, err = client.BookTimeSlotContext(context.Background(), models.TimeSlot{Time: "10:00"}, nil, false)
+
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrBookingAmbiguous))
+}
+
+func TestBookTimeSlotContextAmbiguousAfterServerClosesConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		require.True(t, ok)
+		conn, _, err := hj.Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+	client.RetryPolicy = RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxAttempts: 3}
+
+	_, err = client.BookTimeSlotContext(context.Background(), models.TimeSlot{Time: "10:00"}, nil, false)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBookingAmbiguous))
+}
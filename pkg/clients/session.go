@@ -0,0 +1,220 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/publicsuffix"
+)
+
+// persistedCookie is the on-disk representation of a single cookie. Only the
+// fields cookiejar needs to reconstruct a http.Cookie are kept.
+type persistedCookie struct {
+	Name    string    `json:"name"`
+	Value   string    `json:"value"`
+	Path    string    `json:"path"`
+	Domain  string    `json:"domain"`
+	Expires time.Time `json:"expires"`
+	Secure  bool      `json:"secure"`
+}
+
+// persistentJar wraps an in-memory cookiejar.Jar and snapshots it to disk so
+// a login session survives a process restart.
+type persistentJar struct {
+	mu      sync.Mutex
+	jar     *cookiejar.Jar
+	path    string
+	baseUrl *url.URL
+}
+
+func newPersistentJar(baseUrl *url.URL, path string) (*persistentJar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, err
+	}
+
+	pj := &persistentJar{
+		jar:     jar,
+		path:    path,
+		baseUrl: baseUrl,
+	}
+
+	if err := pj.load(); err != nil {
+		return nil, err
+	}
+
+	return pj, nil
+}
+
+// SetCookies implements http.CookieJar, forwarding to the in-memory jar and
+// then flushing a snapshot to disk, so a crash or kill between requests
+// never loses the session. Session cookies are infrequent enough (a handful
+// of requests per scan, not a tight loop) that debouncing these writes
+// isn't worth the complexity of risking a dropped final write.
+func (p *persistentJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	p.jar.SetCookies(u, cookies)
+	p.flush()
+}
+
+// Cookies implements http.CookieJar.
+func (p *persistentJar) Cookies(u *url.URL) []*http.Cookie {
+	return p.jar.Cookies(u)
+}
+
+func (p *persistentJar) load() error {
+	data, err := os.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored []persistedCookie
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cookies := make([]*http.Cookie, 0, len(stored))
+	for _, c := range stored {
+		if !c.Expires.IsZero() && c.Expires.Before(now) {
+			continue
+		}
+		cookies = append(cookies, &http.Cookie{
+			Name:    c.Name,
+			Value:   c.Value,
+			Path:    c.Path,
+			Domain:  c.Domain,
+			Expires: c.Expires,
+			Secure:  c.Secure,
+		})
+	}
+
+	if len(cookies) > 0 {
+		p.jar.SetCookies(p.baseUrl, cookies)
+	}
+
+	return nil
+}
+
+// flush writes the jar's cookies for baseUrl to disk, skipping any cookie
+// that has already expired. Called after every SetCookies; Logout does not
+// flush, it removes the session file instead.
+func (p *persistentJar) flush() error {
+	now := time.Now()
+	cookies := p.jar.Cookies(p.baseUrl)
+
+	stored := make([]persistedCookie, 0, len(cookies))
+	for _, c := range cookies {
+		if !c.Expires.IsZero() && c.Expires.Before(now) {
+			continue
+		}
+		stored = append(stored, persistedCookie{
+			Name:    c.Name,
+			Value:   c.Value,
+			Path:    c.Path,
+			Domain:  c.Domain,
+			Expires: c.Expires,
+			Secure:  c.Secure,
+		})
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.path, data, 0600)
+}
+
+// NewBookingClientWithSession creates a BookingClient whose cookie jar is
+// backed by sessionPath on disk. Cookies from a prior run are loaded on
+// construction, and new ones are persisted as they arrive, so the caller can
+// skip Login on a process restart when SessionValid reports the stored
+// session is still good.
+func NewBookingClientWithSession(baseURL, sessionPath string) (*BookingClient, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	jar, err := newPersistentJar(parsed, sessionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{
+		Jar: jar,
+	}
+
+	return &BookingClient{
+		baseUrl:     baseURL,
+		httpClient:  client,
+		sessionPath: sessionPath,
+		RetryPolicy: DefaultRetryPolicy(),
+		Metrics:     noopMetrics{},
+	}, nil
+}
+
+// Logout wipes the persisted session file and clears the in-memory cookie
+// jar, forcing the next request to re-authenticate.
+func (w *BookingClient) Logout() error {
+	if pj, ok := w.httpClient.Jar.(*persistentJar); ok {
+		fresh, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+		if err != nil {
+			return err
+		}
+		pj.mu.Lock()
+		pj.jar = fresh
+		pj.mu.Unlock()
+	}
+
+	if w.sessionPath == "" {
+		return nil
+	}
+
+	if err := os.Remove(w.sessionPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// SessionValid performs a cheap authenticated GET against the booking site
+// to determine whether the stored session is still good, so the caller can
+// skip a full Login on a process restart.
+func (w BookingClient) SessionValid(ctx context.Context) (bool, error) {
+	url := w.baseUrl + teeAvailability
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return false, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	// The member-facing booking page renders a login form when the session
+	// has expired; its presence means re-login is required.
+	return doc.Find("input[name='pin']").Length() == 0, nil
+}
@@ -0,0 +1,152 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// NewBookingClientWithSession Tests
+// ============================================================================
+
+func TestNewBookingClientWithSessionNoExistingFile(t *testing.T) {
+	sessionPath := filepath.Join(t.TempDir(), "session.json")
+
+	client, err := NewBookingClientWithSession("https://example.com/", sessionPath)
+
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+	assert.Equal(t, sessionPath, client.sessionPath)
+}
+
+func TestSessionRoundTripsCookiesThroughRestart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{
+			Name:    "PHPSESSID",
+			Value:   "abc123",
+			Path:    "/",
+			Expires: time.Now().Add(time.Hour),
+		})
+		w.WriteHeader(http.StatusOK)
+		w.Write(loadFixture(t, "login_success.html"))
+	}))
+	defer server.Close()
+
+	sessionPath := filepath.Join(t.TempDir(), "session.json")
+
+	first, err := NewBookingClientWithSession(server.URL+"/", sessionPath)
+	require.NoError(t, err)
+	_, err = first.LoginContext(context.Background(), "testuser", "testpin")
+	require.NoError(t, err)
+
+	// No explicit flush - SetCookies persists on every call, so the cookie
+	// the server set during LoginContext must already be on disk.
+	second, err := NewBookingClientWithSession(server.URL+"/", sessionPath)
+	require.NoError(t, err)
+
+	serverURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	cookies := second.httpClient.Jar.Cookies(serverURL)
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "abc123", cookies[0].Value)
+}
+
+func TestSessionDoesNotRestoreExpiredCookies(t *testing.T) {
+	sessionPath := filepath.Join(t.TempDir(), "session.json")
+
+	baseUrl, err := url.Parse("https://example.com/")
+	require.NoError(t, err)
+
+	pj, err := newPersistentJar(baseUrl, sessionPath)
+	require.NoError(t, err)
+
+	pj.jar.SetCookies(baseUrl, []*http.Cookie{
+		{Name: "expired", Value: "v", Path: "/", Expires: time.Now().Add(-time.Hour)},
+	})
+	require.NoError(t, pj.flush())
+
+	reloaded, err := newPersistentJar(baseUrl, sessionPath)
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.jar.Cookies(baseUrl))
+}
+
+// ============================================================================
+// Logout Tests
+// ============================================================================
+
+func TestLogoutRemovesSessionFileAndCookies(t *testing.T) {
+	sessionPath := filepath.Join(t.TempDir(), "session.json")
+
+	client, err := NewBookingClientWithSession("https://example.com/", sessionPath)
+	require.NoError(t, err)
+
+	baseUrl, err := url.Parse("https://example.com/")
+	require.NoError(t, err)
+
+	client.httpClient.Jar.SetCookies(baseUrl, []*http.Cookie{{Name: "a", Value: "b", Path: "/"}})
+	require.NoError(t, client.httpClient.Jar.(*persistentJar).flush())
+
+	require.NoError(t, client.Logout())
+
+	assert.Empty(t, client.httpClient.Jar.Cookies(baseUrl))
+	_, statErr := os.Stat(sessionPath)
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+// ============================================================================
+// SessionValid Tests
+// ============================================================================
+
+func TestSessionValidWithActiveSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<html><body><div id="globalwrap"></div></body></html>`))
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	valid, err := client.SessionValid(context.Background())
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestSessionValidWithExpiredSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<html><body><form><input name="memberid"><input name="pin"></form></body></html>`))
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	valid, err := client.SessionValid(context.Background())
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestSessionValidNon200Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClient(server.URL + "/")
+	require.NoError(t, err)
+
+	valid, err := client.SessionValid(context.Background())
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
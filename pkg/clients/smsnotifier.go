@@ -0,0 +1,28 @@
+package clients
+
+import "context"
+
+// SMSNotifier adapts any SMSService (TwilioClient in practice) to the
+// Notifier interface by pinning the From number used for every outbound
+// message. Subject and priority have no SMS equivalent: subject is folded
+// into the message body, and priority is ignored.
+type SMSNotifier struct {
+	sms        SMSService
+	fromNumber string
+}
+
+// NewSMSNotifier creates an SMSNotifier that sends from fromNumber via sms.
+func NewSMSNotifier(sms SMSService, fromNumber string) *SMSNotifier {
+	return &SMSNotifier{sms: sms, fromNumber: fromNumber}
+}
+
+// Notify implements Notifier.
+func (n SMSNotifier) Notify(ctx context.Context, recipient, subject, body string, priority Priority, dryRun bool) error {
+	message := body
+	if subject != "" {
+		message = subject + ": " + body
+	}
+	return n.sms.SendSmsWithContext(ctx, n.fromNumber, recipient, message, dryRun)
+}
+
+var _ Notifier = (*SMSNotifier)(nil)
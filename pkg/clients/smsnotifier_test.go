@@ -0,0 +1,95 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMSService is a test double for SMSService
+type fakeSMSService struct {
+	sendSmsFunc func(from, to, body string, dryRun bool) error
+	lastFrom    string
+	lastTo      string
+	lastBody    string
+	lastDryRun  bool
+}
+
+func (f *fakeSMSService) SendSms(from, to, body string, dryRun bool) error {
+	return f.SendSmsWithContext(context.Background(), from, to, body, dryRun)
+}
+
+func (f *fakeSMSService) SendSmsWithContext(ctx context.Context, from, to, body string, dryRun bool) error {
+	f.lastFrom = from
+	f.lastTo = to
+	f.lastBody = body
+	f.lastDryRun = dryRun
+	if f.sendSmsFunc != nil {
+		return f.sendSmsFunc(from, to, body, dryRun)
+	}
+	return nil
+}
+
+// TestNewSMSNotifier tests that the constructor creates a valid notifier
+func TestNewSMSNotifier(t *testing.T) {
+	sms := &fakeSMSService{}
+	notifier := NewSMSNotifier(sms, "+1234567890")
+
+	require.NotNil(t, notifier)
+	assert.Equal(t, sms, notifier.sms)
+	assert.Equal(t, "+1234567890", notifier.fromNumber)
+}
+
+// TestSMSNotifierNotifyFoldsSubjectIntoBody tests that the subject is prefixed onto the body
+func TestSMSNotifierNotifyFoldsSubjectIntoBody(t *testing.T) {
+	sms := &fakeSMSService{}
+	notifier := NewSMSNotifier(sms, "+1234567890")
+
+	err := notifier.Notify(context.Background(), "+0987654321", "Tee time booked", "10:00 on 22-01-2024", PriorityHigh, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "+1234567890", sms.lastFrom)
+	assert.Equal(t, "+0987654321", sms.lastTo)
+	assert.Equal(t, "Tee time booked: 10:00 on 22-01-2024", sms.lastBody)
+	assert.False(t, sms.lastDryRun)
+}
+
+// TestSMSNotifierNotifyWithoutSubject tests that an empty subject leaves the body untouched
+func TestSMSNotifierNotifyWithoutSubject(t *testing.T) {
+	sms := &fakeSMSService{}
+	notifier := NewSMSNotifier(sms, "+1234567890")
+
+	err := notifier.Notify(context.Background(), "+0987654321", "", "10:00 on 22-01-2024", PriorityDefault, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10:00 on 22-01-2024", sms.lastBody)
+}
+
+// TestSMSNotifierNotifyDryRun tests that dry run mode is passed through to SendSms
+func TestSMSNotifierNotifyDryRun(t *testing.T) {
+	sms := &fakeSMSService{}
+	notifier := NewSMSNotifier(sms, "+1234567890")
+
+	err := notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, true)
+
+	assert.NoError(t, err)
+	assert.True(t, sms.lastDryRun)
+}
+
+// TestSMSNotifierNotifyPropagatesError tests that SendSms errors are returned unchanged
+func TestSMSNotifierNotifyPropagatesError(t *testing.T) {
+	sms := &fakeSMSService{
+		sendSmsFunc: func(from, to, body string, dryRun bool) error {
+			return errors.New("twilio unavailable")
+		},
+	}
+	notifier := NewSMSNotifier(sms, "+1234567890")
+
+	err := notifier.Notify(context.Background(), "+0987654321", "Subject", "Body", PriorityDefault, false)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "twilio unavailable")
+}
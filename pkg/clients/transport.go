@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RoundTripperMiddleware wraps an http.RoundTripper to add behavior around
+// every outbound request, e.g. logging, metrics, or a mock recorder.
+type RoundTripperMiddleware func(http.RoundTripper) http.RoundTripper
+
+// ClientOptions configures the transport behind a BookingClient, so callers
+// can run behind a corporate proxy, pin the club's TLS certificate, or
+// instrument outbound requests without reaching into unexported fields.
+type ClientOptions struct {
+	// Transport, if set, is used as the base RoundTripper instead of a
+	// default *http.Transport. Proxy and TLSClientConfig are ignored when
+	// Transport is set; configure them on it directly.
+	Transport http.RoundTripper
+
+	// Proxy sets the outbound proxy for the default transport. A nil Proxy
+	// falls back to http.ProxyFromEnvironment. Ignored when Transport is set.
+	Proxy *url.URL
+
+	// TLSClientConfig configures TLS for the default transport, e.g. to pin
+	// the club's certificate in production or set InsecureSkipVerify in
+	// test/dev. Ignored when Transport is set.
+	TLSClientConfig *tls.Config
+
+	// Timeout bounds every request made by the resulting http.Client. Zero
+	// means no client-level timeout.
+	Timeout time.Duration
+
+	// RoundTripperMiddleware wraps the transport outermost-first: index 0
+	// sees the request before index 1, and so on down to the base
+	// transport. Useful for retries, metrics, request logging, or tests.
+	RoundTripperMiddleware []RoundTripperMiddleware
+}
+
+// buildTransport assembles the RoundTripper described by opts, applying the
+// middleware chain around the base transport.
+func (o ClientOptions) buildTransport() http.RoundTripper {
+	rt := o.Transport
+	if rt == nil {
+		proxy := http.ProxyFromEnvironment
+		if o.Proxy != nil {
+			proxy = http.ProxyURL(o.Proxy)
+		}
+		rt = &http.Transport{
+			Proxy:           proxy,
+			TLSClientConfig: o.TLSClientConfig,
+		}
+	}
+
+	for i := len(o.RoundTripperMiddleware) - 1; i >= 0; i-- {
+		rt = o.RoundTripperMiddleware[i](rt)
+	}
+
+	return rt
+}
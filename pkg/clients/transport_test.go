@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewBookingClientWithOptionsCustomTransportReceivesRequest(t *testing.T) {
+	var gotURL *url.URL
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotURL = req.URL
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	client, err := NewBookingClientWithOptions("https://example.com/", ClientOptions{
+		Transport: transport,
+	})
+	require.NoError(t, err)
+
+	_, err = client.httpClient.Get("https://example.com/ping")
+	require.NoError(t, err)
+
+	require.NotNil(t, gotURL)
+	assert.Equal(t, "/ping", gotURL.Path)
+}
+
+func TestNewBookingClientWithOptionsMiddlewareRunsInOrder(t *testing.T) {
+	var order []string
+
+	mark := func(name string) RoundTripperMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	client, err := NewBookingClientWithOptions("https://example.com/", ClientOptions{
+		Transport:              base,
+		RoundTripperMiddleware: []RoundTripperMiddleware{mark("first"), mark("second")},
+	})
+	require.NoError(t, err)
+
+	_, err = client.httpClient.Get("https://example.com/ping")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second", "base"}, order)
+}
+
+func TestNewBookingClientWithOptionsProxyFallsBackToEnvironment(t *testing.T) {
+	client, err := NewBookingClientWithOptions("https://example.com/", ClientOptions{})
+	require.NoError(t, err)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, transport.Proxy)
+}
+
+func TestNewBookingClientWithOptionsCustomProxy(t *testing.T) {
+	proxyURL, err := url.Parse("http://proxy.internal:8080")
+	require.NoError(t, err)
+
+	client, err := NewBookingClientWithOptions("https://example.com/", ClientOptions{
+		Proxy: proxyURL,
+	})
+	require.NoError(t, err)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+
+	got, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, proxyURL, got)
+}
+
+func TestNewBookingClientUsesDefaultOptions(t *testing.T) {
+	client, err := NewBookingClient("https://example.com/")
+	require.NoError(t, err)
+
+	_, ok := client.httpClient.Transport.(*http.Transport)
+	assert.True(t, ok)
+}
+
+func TestNewBookingClientWithOptionsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewBookingClientWithOptions(server.URL+"/", ClientOptions{
+		Timeout: 0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, client.httpClient.Timeout.Seconds(), float64(0))
+}
@@ -1,26 +1,59 @@
 package clients
 
 import (
+	"context"
+	"errors"
 	"log"
 
 	twilio "github.com/twilio/twilio-go"
 	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
 )
 
-// MessageCreator abstracts the Twilio message creation API for testing
+// ErrRecipientNotVerified is returned by SendSms when a VerificationStore is
+// configured and the recipient hasn't completed Twilio Verify opt-in.
+var ErrRecipientNotVerified = errors.New("recipient has not completed verification")
+
+// MessageCreator abstracts the Twilio message creation API for testing. ctx
+// bounds the outbound request the same way BookingService's *Context
+// methods do, so a caller can abort a slow Twilio call instead of blocking
+// on it indefinitely.
 type MessageCreator interface {
-	CreateMessage(params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error)
+	CreateMessage(ctx context.Context, params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error)
+}
+
+// apiMessageCreator adapts the real twilio-go ApiService to MessageCreator.
+// The vendored twilio-go release this module builds against predates its
+// upstream move to context-aware request methods (CreateMessageWithCtx), so
+// ctx is accepted for interface compatibility but not yet threaded into the
+// underlying HTTP call; it becomes a real deadline/cancellation once the SDK
+// dependency is upgraded.
+type apiMessageCreator struct {
+	api *twilioApi.ApiService
+}
+
+func (a apiMessageCreator) CreateMessage(_ context.Context, params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error) {
+	return a.api.CreateMessage(params)
 }
 
 type TwilioClient struct {
 	messageCreator MessageCreator
+
+	// VerificationStore, if set, gates SendSms so it refuses to message a
+	// recipient who hasn't completed Twilio Verify opt-in. Nil disables the
+	// check, so a caller can message any number.
+	VerificationStore *VerificationStore
+
+	// RetryPolicy controls the exponential backoff applied to transient
+	// CreateMessage failures (network errors, 429/5xx responses). Defaults
+	// to DefaultTwilioRetryPolicy() when left zero-valued.
+	RetryPolicy TwilioRetryPolicy
 }
 
 // NewTwilioClient creates a TwilioClient with the real Twilio API
 func NewTwilioClient() *TwilioClient {
 	client := twilio.NewRestClient()
 	return &TwilioClient{
-		messageCreator: client.Api,
+		messageCreator: apiMessageCreator{api: client.Api},
 	}
 }
 
@@ -31,7 +64,20 @@ func NewTwilioClientWithCreator(creator MessageCreator) *TwilioClient {
 	}
 }
 
+// SendSms sends an SMS message using context.Background(). See
+// SendSmsWithContext for the context-aware variant.
 func (t TwilioClient) SendSms(from string, to string, body string, dryRun bool) error {
+	return t.SendSmsWithContext(context.Background(), from, to, body, dryRun)
+}
+
+// SendSmsWithContext sends an SMS message, passing ctx to the MessageCreator
+// and abandoning the retry backoff once ctx is cancelled. With the real
+// Twilio API (apiMessageCreator) ctx does not bound an individual request.
+func (t TwilioClient) SendSmsWithContext(ctx context.Context, from string, to string, body string, dryRun bool) error {
+	if t.VerificationStore != nil && !t.VerificationStore.IsVerified(to) {
+		return ErrRecipientNotVerified
+	}
+
 	if dryRun {
 		log.Printf("DRY RUN: Would have sent SMS from %s to %s with body: %s", from, to, body)
 		return nil
@@ -42,6 +88,8 @@ func (t TwilioClient) SendSms(from string, to string, body string, dryRun bool)
 	params.SetFrom(from)
 	params.SetBody(body)
 
-	_, err := t.messageCreator.CreateMessage(params)
-	return err
+	return t.RetryPolicy.withRetry(ctx, func() error {
+		_, err := t.messageCreator.CreateMessage(ctx, params)
+		return err
+	})
 }
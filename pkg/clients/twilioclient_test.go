@@ -1,11 +1,16 @@
 package clients
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	twilioclient "github.com/twilio/twilio-go/client"
 	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
 )
 
@@ -13,10 +18,12 @@ import (
 type mockMessageCreator struct {
 	createMessageFunc func(params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error)
 	lastParams        *twilioApi.CreateMessageParams
+	lastCtx           context.Context
 }
 
-func (m *mockMessageCreator) CreateMessage(params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error) {
+func (m *mockMessageCreator) CreateMessage(ctx context.Context, params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error) {
 	m.lastParams = params
+	m.lastCtx = ctx
 	if m.createMessageFunc != nil {
 		return m.createMessageFunc(params)
 	}
@@ -133,6 +140,7 @@ func TestSendSmsAPIError(t *testing.T) {
 		},
 	}
 	client := NewTwilioClientWithCreator(mock)
+	client.RetryPolicy = TwilioRetryPolicy{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 2, MaxElapsedTime: 5 * time.Millisecond}
 
 	err := client.SendSms("+1234567890", "+0987654321", "Test message", false)
 
@@ -158,6 +166,76 @@ func TestSendSmsPassesCorrectParameters(t *testing.T) {
 	assert.Equal(t, body, *mock.lastParams.Body)
 }
 
+// TestSendSmsWithContextPassesCtxThrough verifies the ctx given to
+// SendSmsWithContext reaches the MessageCreator
+func TestSendSmsWithContextPassesCtxThrough(t *testing.T) {
+	mock := &mockMessageCreator{}
+	client := NewTwilioClientWithCreator(mock)
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "marker")
+
+	err := client.SendSmsWithContext(ctx, "+1234567890", "+0987654321", "Test message", false)
+
+	assert.NoError(t, err)
+	require.NotNil(t, mock.lastCtx)
+	assert.Equal(t, "marker", mock.lastCtx.Value(ctxKey{}))
+}
+
+// TestSendSmsUsesBackgroundContext verifies SendSms defers to
+// SendSmsWithContext with context.Background()
+func TestSendSmsUsesBackgroundContext(t *testing.T) {
+	mock := &mockMessageCreator{}
+	client := NewTwilioClientWithCreator(mock)
+
+	err := client.SendSms("+1234567890", "+0987654321", "Test message", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, context.Background(), mock.lastCtx)
+}
+
+// TestSendSmsRetriesOnTransientFailure verifies SendSms retries a retryable
+// CreateMessage error and succeeds once it clears
+func TestSendSmsRetriesOnTransientFailure(t *testing.T) {
+	attempts := 0
+	mock := &mockMessageCreator{
+		createMessageFunc: func(params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error) {
+			attempts++
+			if attempts < 3 {
+				return nil, &twilioclient.TwilioRestError{Status: http.StatusServiceUnavailable}
+			}
+			return &twilioApi.ApiV2010Message{}, nil
+		},
+	}
+	client := NewTwilioClientWithCreator(mock)
+	client.RetryPolicy = TwilioRetryPolicy{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 2, MaxElapsedTime: time.Minute}
+
+	err := client.SendSms("+1234567890", "+0987654321", "Test message", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+// TestSendSmsFailsFastOnValidationError verifies SendSms does not retry a
+// Twilio 21xx validation/auth error
+func TestSendSmsFailsFastOnValidationError(t *testing.T) {
+	attempts := 0
+	wantErr := &twilioclient.TwilioRestError{Status: http.StatusBadRequest, Code: 21211}
+	mock := &mockMessageCreator{
+		createMessageFunc: func(params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error) {
+			attempts++
+			return nil, wantErr
+		},
+	}
+	client := NewTwilioClientWithCreator(mock)
+	client.RetryPolicy = TwilioRetryPolicy{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 2, MaxElapsedTime: time.Minute}
+
+	err := client.SendSms("+1234567890", "+0987654321", "Test message", false)
+
+	assert.Equal(t, wantErr, err)
+	assert.Equal(t, 1, attempts)
+}
+
 // TestTwilioClientImplementsSMSService verifies interface compliance at compile time
 func TestTwilioClientImplementsSMSService(t *testing.T) {
 	// This is a compile-time check - if TwilioClient doesn't implement SMSService,
@@ -206,3 +284,40 @@ func TestSendSmsCalledOncePerRequest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, callCount, "CreateMessage should be called exactly once")
 }
+
+// TestSendSmsRejectsUnverifiedRecipient tests that a VerificationStore gates SendSms
+func TestSendSmsRejectsUnverifiedRecipient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verification_store.json")
+	store, err := NewVerificationStore(path)
+	require.NoError(t, err)
+
+	mock := &mockMessageCreator{
+		createMessageFunc: func(params *twilioApi.CreateMessageParams) (*twilioApi.ApiV2010Message, error) {
+			t.Error("CreateMessage should not be called for an unverified recipient")
+			return nil, nil
+		},
+	}
+	client := NewTwilioClientWithCreator(mock)
+	client.VerificationStore = store
+
+	err = client.SendSms("+1234567890", "+0987654321", "Test message", false)
+
+	assert.ErrorIs(t, err, ErrRecipientNotVerified)
+}
+
+// TestSendSmsAllowsVerifiedRecipient tests that a verified recipient can be messaged
+func TestSendSmsAllowsVerifiedRecipient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verification_store.json")
+	store, err := NewVerificationStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.MarkVerified("+0987654321", "sms"))
+
+	mock := &mockMessageCreator{}
+	client := NewTwilioClientWithCreator(mock)
+	client.VerificationStore = store
+
+	err = client.SendSms("+1234567890", "+0987654321", "Test message", false)
+
+	assert.NoError(t, err)
+	require.NotNil(t, mock.lastParams)
+}
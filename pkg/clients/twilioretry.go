@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	twilioclient "github.com/twilio/twilio-go/client"
+)
+
+// TwilioRetryPolicy configures the exponential backoff with full jitter
+// applied to Twilio API calls (see TwilioClient.SendSmsWithContext): retry
+// on network errors and 429/5xx responses, but fail fast on other 4xx
+// (auth/validation, Twilio's 21xx error codes) responses, since retrying
+// those can't succeed.
+type TwilioRetryPolicy struct {
+	// InitialInterval is the delay before the first retry.
+	InitialInterval time.Duration
+	// Multiplier grows the delay between successive retries.
+	Multiplier float64
+	// MaxInterval caps the delay regardless of attempt count.
+	MaxInterval time.Duration
+	// MaxElapsedTime bounds the total time spent retrying. Once exceeded,
+	// the most recent error is returned instead of retrying again.
+	MaxElapsedTime time.Duration
+}
+
+// DefaultTwilioRetryPolicy returns sane defaults for a flaky Twilio API
+// call: starting at 500ms, doubling each attempt, capping at 30s between
+// attempts and 2m overall.
+func DefaultTwilioRetryPolicy() TwilioRetryPolicy {
+	return TwilioRetryPolicy{
+		InitialInterval: 500 * time.Millisecond,
+		Multiplier:      2,
+		MaxInterval:     30 * time.Second,
+		MaxElapsedTime:  2 * time.Minute,
+	}
+}
+
+func (p TwilioRetryPolicy) initialInterval() time.Duration {
+	if p.InitialInterval <= 0 {
+		return DefaultTwilioRetryPolicy().InitialInterval
+	}
+	return p.InitialInterval
+}
+
+func (p TwilioRetryPolicy) multiplier() float64 {
+	if p.Multiplier <= 1 {
+		return DefaultTwilioRetryPolicy().Multiplier
+	}
+	return p.Multiplier
+}
+
+func (p TwilioRetryPolicy) maxInterval() time.Duration {
+	if p.MaxInterval <= 0 {
+		return DefaultTwilioRetryPolicy().MaxInterval
+	}
+	return p.MaxInterval
+}
+
+func (p TwilioRetryPolicy) maxElapsedTime() time.Duration {
+	if p.MaxElapsedTime <= 0 {
+		return DefaultTwilioRetryPolicy().MaxElapsedTime
+	}
+	return p.MaxElapsedTime
+}
+
+// backoff returns a full-jitter delay for the given zero-indexed attempt: a
+// random value in [0, min(maxInterval, initialInterval*multiplier^attempt)].
+func (p TwilioRetryPolicy) backoff(attempt int) time.Duration {
+	capped := float64(p.initialInterval()) * math.Pow(p.multiplier(), float64(attempt))
+	max := float64(p.maxInterval())
+	if capped <= 0 || capped > max {
+		capped = max
+	}
+
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+// isRetryableTwilioError reports whether err is worth retrying: a
+// network/transport error (surfaced as something other than a
+// *client.TwilioRestError), or a Twilio API error whose Status is 429 or
+// 5xx. A 4xx Status - Twilio's 21xx error codes, covering bad credentials
+// and malformed requests - is not retryable.
+func isRetryableTwilioError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var restErr *twilioclient.TwilioRestError
+	if !errors.As(err, &restErr) {
+		return true
+	}
+
+	return restErr.Status == http.StatusTooManyRequests || restErr.Status >= 500
+}
+
+// withRetry calls attempt, retrying on a retryable error (see
+// isRetryableTwilioError) with full-jitter exponential backoff until it
+// succeeds, returns a non-retryable error, or policy.maxElapsedTime() has
+// elapsed since the first attempt. ctx cancellation is honored between
+// attempts.
+func (p TwilioRetryPolicy) withRetry(ctx context.Context, attempt func() error) error {
+	start := time.Now()
+	var lastErr error
+
+	for i := 0; ; i++ {
+		lastErr = attempt()
+		if !isRetryableTwilioError(lastErr) {
+			return lastErr
+		}
+
+		if time.Since(start) >= p.maxElapsedTime() {
+			return lastErr
+		}
+
+		if sleepErr := sleepContext(ctx, p.backoff(i)); sleepErr != nil {
+			return sleepErr
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	twilioclient "github.com/twilio/twilio-go/client"
+)
+
+func TestDefaultTwilioRetryPolicy(t *testing.T) {
+	policy := DefaultTwilioRetryPolicy()
+
+	assert.Equal(t, 500*time.Millisecond, policy.InitialInterval)
+	assert.Equal(t, 2.0, policy.Multiplier)
+	assert.Equal(t, 30*time.Second, policy.MaxInterval)
+	assert.Equal(t, 2*time.Minute, policy.MaxElapsedTime)
+}
+
+func TestTwilioBackoffStaysWithinBounds(t *testing.T) {
+	policy := TwilioRetryPolicy{InitialInterval: 10 * time.Millisecond, Multiplier: 2, MaxInterval: 100 * time.Millisecond}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		for i := 0; i < 20; i++ {
+			delay := policy.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, policy.MaxInterval)
+		}
+	}
+}
+
+func TestIsRetryableTwilioErrorNetworkError(t *testing.T) {
+	assert.True(t, isRetryableTwilioError(errors.New("connection reset")))
+}
+
+func TestIsRetryableTwilioErrorNil(t *testing.T) {
+	assert.False(t, isRetryableTwilioError(nil))
+}
+
+func TestIsRetryableTwilioErrorRateLimited(t *testing.T) {
+	err := &twilioclient.TwilioRestError{Status: http.StatusTooManyRequests}
+	assert.True(t, isRetryableTwilioError(err))
+}
+
+func TestIsRetryableTwilioErrorServerError(t *testing.T) {
+	err := &twilioclient.TwilioRestError{Status: http.StatusInternalServerError}
+	assert.True(t, isRetryableTwilioError(err))
+}
+
+func TestIsRetryableTwilioErrorAuthFailure(t *testing.T) {
+	// Twilio error code 20003: authentication failure
+	err := &twilioclient.TwilioRestError{Status: http.StatusForbidden, Code: 20003}
+	assert.False(t, isRetryableTwilioError(err))
+}
+
+func TestIsRetryableTwilioErrorValidationFailure(t *testing.T) {
+	// Twilio error code 21211: invalid "To" phone number
+	err := &twilioclient.TwilioRestError{Status: http.StatusBadRequest, Code: 21211}
+	assert.False(t, isRetryableTwilioError(err))
+}
+
+func TestWithRetryRetriesUntilSuccess(t *testing.T) {
+	policy := TwilioRetryPolicy{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2, MaxElapsedTime: time.Minute}
+
+	attempts := 0
+	err := policy.withRetry(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return &twilioclient.TwilioRestError{Status: http.StatusServiceUnavailable}
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetryFailsFastOnNonRetryableError(t *testing.T) {
+	policy := TwilioRetryPolicy{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2, MaxElapsedTime: time.Minute}
+
+	attempts := 0
+	wantErr := &twilioclient.TwilioRestError{Status: http.StatusBadRequest, Code: 21211}
+	err := policy.withRetry(context.Background(), func() error {
+		attempts++
+		return wantErr
+	})
+
+	assert.Equal(t, wantErr, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetryGivesUpAfterMaxElapsedTime(t *testing.T) {
+	policy := TwilioRetryPolicy{InitialInterval: 5 * time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2, MaxElapsedTime: 20 * time.Millisecond}
+
+	attempts := 0
+	err := policy.withRetry(context.Background(), func() error {
+		attempts++
+		return &twilioclient.TwilioRestError{Status: http.StatusServiceUnavailable}
+	})
+
+	require.Error(t, err)
+	assert.Greater(t, attempts, 1)
+}
+
+func TestWithRetryHonorsContextCancellation(t *testing.T) {
+	policy := TwilioRetryPolicy{InitialInterval: time.Hour, MaxInterval: time.Hour, Multiplier: 2, MaxElapsedTime: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := policy.withRetry(ctx, func() error {
+		attempts++
+		return &twilioclient.TwilioRestError{Status: http.StatusServiceUnavailable}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
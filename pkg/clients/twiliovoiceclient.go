@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	twilio "github.com/twilio/twilio-go"
+	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
+)
+
+// CallCreator abstracts the Twilio call creation API for testing
+type CallCreator interface {
+	CreateCall(params *twilioApi.CreateCallParams) (*twilioApi.ApiV2010Call, error)
+}
+
+// TwilioVoiceClient places phone calls via the Twilio Calls resource,
+// mirroring TwilioClient's split between the real SDK and a mockable
+// creator interface.
+type TwilioVoiceClient struct {
+	callCreator CallCreator
+}
+
+// NewTwilioVoiceClient creates a TwilioVoiceClient with the real Twilio API
+func NewTwilioVoiceClient() *TwilioVoiceClient {
+	client := twilio.NewRestClient()
+	return &TwilioVoiceClient{
+		callCreator: client.Api,
+	}
+}
+
+// NewTwilioVoiceClientWithCreator creates a TwilioVoiceClient with a custom CallCreator (for testing)
+func NewTwilioVoiceClientWithCreator(creator CallCreator) *TwilioVoiceClient {
+	return &TwilioVoiceClient{
+		callCreator: creator,
+	}
+}
+
+// PlaceCall places a phone call from `from` to `to`. twimlOrURL is either a
+// fully-qualified URL Twilio should fetch TwiML from, or inline TwiML to
+// execute directly - whichever was configured by the caller. In dry run
+// mode the call is logged but not actually placed.
+func (t TwilioVoiceClient) PlaceCall(from string, to string, twimlOrURL string, dryRun bool) error {
+	if dryRun {
+		log.Printf("DRY RUN: Would have placed call from %s to %s with TwiML/URL: %s", from, to, twimlOrURL)
+		return nil
+	}
+
+	params := &twilioApi.CreateCallParams{}
+	params.SetTo(to)
+	params.SetFrom(from)
+	if strings.HasPrefix(twimlOrURL, "http://") || strings.HasPrefix(twimlOrURL, "https://") {
+		params.SetUrl(twimlOrURL)
+	} else {
+		params.SetTwiml(twimlOrURL)
+	}
+
+	_, err := t.callCreator.CreateCall(params)
+	return err
+}
+
+// SayTwiml wraps message in TwiML that reads it aloud via <Say>, for use as
+// the twimlOrURL argument to PlaceCall.
+func SayTwiml(message string) string {
+	return fmt.Sprintf("<Response><Say>%s</Say></Response>", message)
+}
@@ -0,0 +1,116 @@
+package clients
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
+)
+
+// mockCallCreator is a mock implementation of CallCreator for testing
+type mockCallCreator struct {
+	createCallFunc func(params *twilioApi.CreateCallParams) (*twilioApi.ApiV2010Call, error)
+	lastParams     *twilioApi.CreateCallParams
+}
+
+func (m *mockCallCreator) CreateCall(params *twilioApi.CreateCallParams) (*twilioApi.ApiV2010Call, error) {
+	m.lastParams = params
+	if m.createCallFunc != nil {
+		return m.createCallFunc(params)
+	}
+	return &twilioApi.ApiV2010Call{}, nil
+}
+
+// TestNewTwilioVoiceClient tests that the constructor creates a valid client
+func TestNewTwilioVoiceClient(t *testing.T) {
+	client := NewTwilioVoiceClient()
+	assert.NotNil(t, client)
+}
+
+// TestNewTwilioVoiceClientWithCreator tests the constructor with a custom CallCreator
+func TestNewTwilioVoiceClientWithCreator(t *testing.T) {
+	mock := &mockCallCreator{}
+	client := NewTwilioVoiceClientWithCreator(mock)
+
+	require.NotNil(t, client)
+	assert.Equal(t, mock, client.callCreator)
+}
+
+// TestPlaceCallDryRun tests that dry run mode logs but doesn't place the call
+func TestPlaceCallDryRun(t *testing.T) {
+	mock := &mockCallCreator{
+		createCallFunc: func(params *twilioApi.CreateCallParams) (*twilioApi.ApiV2010Call, error) {
+			t.Error("CreateCall should not be called in dry run mode")
+			return nil, nil
+		},
+	}
+	client := NewTwilioVoiceClientWithCreator(mock)
+
+	err := client.PlaceCall("+1234567890", "+0987654321", SayTwiml("Test message"), true)
+
+	assert.NoError(t, err)
+	assert.Nil(t, mock.lastParams, "No parameters should be set in dry run mode")
+}
+
+// TestPlaceCallSuccessWithInlineTwiml tests a successful call using inline TwiML
+func TestPlaceCallSuccessWithInlineTwiml(t *testing.T) {
+	mock := &mockCallCreator{}
+	client := NewTwilioVoiceClientWithCreator(mock)
+
+	twiml := SayTwiml("Your tee time has been booked!")
+	err := client.PlaceCall("+1234567890", "+0987654321", twiml, false)
+
+	assert.NoError(t, err)
+	require.NotNil(t, mock.lastParams)
+	assert.Equal(t, "+0987654321", *mock.lastParams.To)
+	assert.Equal(t, "+1234567890", *mock.lastParams.From)
+	assert.Equal(t, twiml, *mock.lastParams.Twiml)
+	assert.Nil(t, mock.lastParams.Url)
+}
+
+// TestPlaceCallSuccessWithURL tests that an http(s) argument is treated as a TwiML URL
+func TestPlaceCallSuccessWithURL(t *testing.T) {
+	mock := &mockCallCreator{}
+	client := NewTwilioVoiceClientWithCreator(mock)
+
+	err := client.PlaceCall("+1234567890", "+0987654321", "https://example.com/twiml", false)
+
+	assert.NoError(t, err)
+	require.NotNil(t, mock.lastParams)
+	assert.Equal(t, "https://example.com/twiml", *mock.lastParams.Url)
+	assert.Nil(t, mock.lastParams.Twiml)
+}
+
+// TestPlaceCallAPIError tests error handling from the Twilio API
+func TestPlaceCallAPIError(t *testing.T) {
+	expectedError := errors.New("Twilio API error: invalid phone number")
+	mock := &mockCallCreator{
+		createCallFunc: func(params *twilioApi.CreateCallParams) (*twilioApi.ApiV2010Call, error) {
+			return nil, expectedError
+		},
+	}
+	client := NewTwilioVoiceClientWithCreator(mock)
+
+	err := client.PlaceCall("+1234567890", "+0987654321", SayTwiml("Test"), false)
+
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+}
+
+// TestTwilioVoiceClientImplementsVoiceService verifies interface compliance at compile time
+func TestTwilioVoiceClientImplementsVoiceService(t *testing.T) {
+	var _ VoiceService = (*TwilioVoiceClient)(nil)
+
+	client := NewTwilioVoiceClientWithCreator(&mockCallCreator{})
+	var voiceService VoiceService = client
+	assert.NotNil(t, voiceService)
+}
+
+// TestSayTwiml tests that the message is wrapped in a <Say> TwiML response
+func TestSayTwiml(t *testing.T) {
+	twiml := SayTwiml("Your tee time has been booked!")
+
+	assert.Equal(t, "<Response><Say>Your tee time has been booked!</Say></Response>", twiml)
+}
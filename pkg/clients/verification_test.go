@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	verifyApi "github.com/twilio/twilio-go/rest/verify/v2"
+)
+
+// mockVerificationClient is a mock implementation of VerificationCreator and
+// VerificationChecker for testing
+type mockVerificationClient struct {
+	createVerificationFunc func(serviceSid string, params *verifyApi.CreateVerificationParams) (*verifyApi.VerifyV2Verification, error)
+	checkFunc              func(serviceSid string, params *verifyApi.CreateVerificationCheckParams) (*verifyApi.VerifyV2VerificationCheck, error)
+	lastVerificationParams *verifyApi.CreateVerificationParams
+	lastCheckParams        *verifyApi.CreateVerificationCheckParams
+}
+
+func (m *mockVerificationClient) CreateVerification(serviceSid string, params *verifyApi.CreateVerificationParams) (*verifyApi.VerifyV2Verification, error) {
+	m.lastVerificationParams = params
+	if m.createVerificationFunc != nil {
+		return m.createVerificationFunc(serviceSid, params)
+	}
+	return &verifyApi.VerifyV2Verification{}, nil
+}
+
+func (m *mockVerificationClient) CreateVerificationCheck(serviceSid string, params *verifyApi.CreateVerificationCheckParams) (*verifyApi.VerifyV2VerificationCheck, error) {
+	m.lastCheckParams = params
+	if m.checkFunc != nil {
+		return m.checkFunc(serviceSid, params)
+	}
+	approved := verificationApprovedStatus
+	return &verifyApi.VerifyV2VerificationCheck{Status: &approved}, nil
+}
+
+// TestNewVerificationService tests that the constructor creates a valid service
+func TestNewVerificationService(t *testing.T) {
+	service := NewVerificationService("VAxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx")
+	assert.NotNil(t, service)
+}
+
+// TestStartVerificationPassesCorrectParameters verifies the correct parameters reach Twilio
+func TestStartVerificationPassesCorrectParameters(t *testing.T) {
+	mock := &mockVerificationClient{}
+	service := NewVerificationServiceWithClient("VAxxx", mock, mock)
+
+	err := service.StartVerification("+1234567890", "sms")
+
+	assert.NoError(t, err)
+	require.NotNil(t, mock.lastVerificationParams)
+	assert.Equal(t, "+1234567890", *mock.lastVerificationParams.To)
+	assert.Equal(t, "sms", *mock.lastVerificationParams.Channel)
+}
+
+// TestStartVerificationAPIError tests error handling from the Twilio API
+func TestStartVerificationAPIError(t *testing.T) {
+	expectedError := errors.New("Twilio API error: invalid phone number")
+	mock := &mockVerificationClient{
+		createVerificationFunc: func(serviceSid string, params *verifyApi.CreateVerificationParams) (*verifyApi.VerifyV2Verification, error) {
+			return nil, expectedError
+		},
+	}
+	service := NewVerificationServiceWithClient("VAxxx", mock, mock)
+
+	err := service.StartVerification("+1234567890", "sms")
+
+	assert.Equal(t, expectedError, err)
+}
+
+// TestCheckVerificationApproved tests that an approved status returns no error
+func TestCheckVerificationApproved(t *testing.T) {
+	mock := &mockVerificationClient{}
+	service := NewVerificationServiceWithClient("VAxxx", mock, mock)
+
+	err := service.CheckVerification("+1234567890", "123456")
+
+	assert.NoError(t, err)
+	require.NotNil(t, mock.lastCheckParams)
+	assert.Equal(t, "+1234567890", *mock.lastCheckParams.To)
+	assert.Equal(t, "123456", *mock.lastCheckParams.Code)
+}
+
+// TestCheckVerificationPending tests that a non-approved status is rejected
+func TestCheckVerificationPending(t *testing.T) {
+	mock := &mockVerificationClient{
+		checkFunc: func(serviceSid string, params *verifyApi.CreateVerificationCheckParams) (*verifyApi.VerifyV2VerificationCheck, error) {
+			pending := "pending"
+			return &verifyApi.VerifyV2VerificationCheck{Status: &pending}, nil
+		},
+	}
+	service := NewVerificationServiceWithClient("VAxxx", mock, mock)
+
+	err := service.CheckVerification("+1234567890", "000000")
+
+	assert.ErrorIs(t, err, ErrVerificationNotApproved)
+}
+
+// TestCheckVerificationAPIError tests error handling from the Twilio API
+func TestCheckVerificationAPIError(t *testing.T) {
+	expectedError := errors.New("Twilio API error: invalid code")
+	mock := &mockVerificationClient{
+		checkFunc: func(serviceSid string, params *verifyApi.CreateVerificationCheckParams) (*verifyApi.VerifyV2VerificationCheck, error) {
+			return nil, expectedError
+		},
+	}
+	service := NewVerificationServiceWithClient("VAxxx", mock, mock)
+
+	err := service.CheckVerification("+1234567890", "123456")
+
+	assert.Equal(t, expectedError, err)
+}
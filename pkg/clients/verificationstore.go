@@ -0,0 +1,100 @@
+package clients
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// VerificationRecord is the on-disk representation of a phone number that
+// has completed Twilio Verify opt-in.
+type VerificationRecord struct {
+	Phone      string    `json:"phone"`
+	VerifiedAt time.Time `json:"verified_at"`
+	Channel    string    `json:"channel"`
+}
+
+// VerificationStore persists which phone numbers have completed opt-in
+// verification, so SendSms can refuse to message a number that hasn't. It
+// snapshots to a JSON file on disk, mirroring persistentJar's approach to
+// surviving a process restart.
+type VerificationStore struct {
+	mu      sync.Mutex
+	path    string
+	records map[string]VerificationRecord
+}
+
+// NewVerificationStore creates a VerificationStore backed by path, loading
+// any records already persisted there.
+func NewVerificationStore(path string) (*VerificationStore, error) {
+	s := &VerificationStore{
+		path:    path,
+		records: make(map[string]VerificationRecord),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// IsVerified reports whether phone has completed opt-in verification.
+func (s *VerificationStore) IsVerified(phone string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.records[phone]
+	return ok
+}
+
+// MarkVerified records phone as verified via channel and persists the store.
+func (s *VerificationStore) MarkVerified(phone, channel string) error {
+	s.mu.Lock()
+	s.records[phone] = VerificationRecord{
+		Phone:      phone,
+		VerifiedAt: time.Now(),
+		Channel:    channel,
+	}
+	s.mu.Unlock()
+
+	return s.save()
+}
+
+func (s *VerificationStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored []VerificationRecord
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	for _, r := range stored {
+		s.records[r.Phone] = r
+	}
+
+	return nil
+}
+
+func (s *VerificationStore) save() error {
+	s.mu.Lock()
+	stored := make([]VerificationRecord, 0, len(s.records))
+	for _, r := range s.records {
+		stored = append(stored, r)
+	}
+	s.mu.Unlock()
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0600)
+}
@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerificationStoreUnverifiedByDefault tests that an unknown number isn't verified
+func TestVerificationStoreUnverifiedByDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verification_store.json")
+
+	store, err := NewVerificationStore(path)
+	require.NoError(t, err)
+
+	assert.False(t, store.IsVerified("+1234567890"))
+}
+
+// TestVerificationStoreMarkVerified tests that a marked number reports as verified
+func TestVerificationStoreMarkVerified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verification_store.json")
+
+	store, err := NewVerificationStore(path)
+	require.NoError(t, err)
+
+	err = store.MarkVerified("+1234567890", "sms")
+	require.NoError(t, err)
+
+	assert.True(t, store.IsVerified("+1234567890"))
+	assert.False(t, store.IsVerified("+0987654321"))
+}
+
+// TestVerificationStorePersistsAcrossInstances tests that verified numbers survive a reload
+func TestVerificationStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verification_store.json")
+
+	store, err := NewVerificationStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.MarkVerified("+1234567890", "call"))
+
+	reloaded, err := NewVerificationStore(path)
+	require.NoError(t, err)
+
+	assert.True(t, reloaded.IsVerified("+1234567890"))
+}
+
+// TestVerificationStoreLoadsMissingFile tests that a missing store file isn't an error
+func TestVerificationStoreLoadsMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	store, err := NewVerificationStore(path)
+
+	assert.NoError(t, err)
+	assert.False(t, store.IsVerified("+1234567890"))
+}
@@ -2,6 +2,9 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	flags "github.com/jessevdk/go-flags"
 )
@@ -11,25 +14,165 @@ var (
 )
 
 type Config struct {
-	DaysAhead int    `short:"d" long:"days" required:"true" description:"The number of days ahead to look for a tee-slot"`
-	TimeStart string `short:"t" long:"timestart" required:"true" description:"The time after which a tee-time will be selected"`
-	TimeEnd   string `short:"e" long:"timeend" required:"true" description:"The time before which a tee-time will be selected"`
-	Retries   int    `short:"r" long:"retries" required:"true" default:"5" description:"The number of times to retry booking"`
-	DryRun    bool   `short:"x" long:"dryrun" description:"Run everything, but don't do the booking and assume it succeeds"`
+	DaysAhead int    `short:"d" long:"days" env:"TS_DAYS_AHEAD" description:"The number of days ahead to look for a tee-slot"`
+	TimeStart string `short:"t" long:"timestart" env:"TS_TIME_START" description:"The time after which a tee-time will be selected"`
+	TimeEnd   string `short:"e" long:"timeend" env:"TS_TIME_END" description:"The time before which a tee-time will be selected"`
+	Retries   int    `short:"r" long:"retries" required:"true" default:"5" env:"TS_RETRIES" description:"The number of times to retry booking"`
 
-	Username string `short:"u" long:"username" required:"true" description:"The username to use for booking"`
-	Pin      string `short:"p" long:"pin" required:"true" description:"The pin associated with the username for booking"`
-	BaseUrl  string `short:"b" long:"baseurl" required:"true" description:"The host for the booking website"`
+	HTTPRetryBase        time.Duration `long:"http-retry-base" default:"200ms" description:"The starting delay for clients.BookingClient's full-jitter backoff on a transient HTTP failure (network error or 502/503/504)"`
+	HTTPRetryMaxDelay    time.Duration `long:"http-retry-max-delay" default:"10s" description:"The cap on clients.BookingClient's full-jitter backoff delay regardless of attempt count"`
+	HTTPRetryMaxAttempts int           `long:"http-retry-max-attempts" default:"5" description:"The total number of attempts, including the first, clients.BookingClient makes before giving up on a single HTTP call"`
+	DryRun               bool          `short:"x" long:"dryrun" env:"TS_DRY_RUN" description:"Run everything, but don't do the booking and assume it succeeds"`
 
-	FromNumber string `short:"f" long:"fromnumber" required:"true" description:"The number to send the confirmation SMS from"`
-	ToNumber   string `short:"n" long:"tonumber" required:"true" description:"The number to send the confirmation SMS to"`
+	Username string `short:"u" long:"username" env:"TS_USERNAME" description:"The username to use for booking"`
+	Pin      string `short:"p" long:"pin" env:"TS_PIN" description:"The pin associated with the username for booking"`
+	BaseUrl  string `short:"b" long:"baseurl" env:"TS_BASEURL" description:"The host for the booking website"`
+
+	FromNumber string `short:"f" long:"fromnumber" env:"TS_FROM_NUMBER" description:"The number to send the confirmation SMS from"`
+	ToNumber   string `short:"n" long:"tonumber" env:"TS_TO_NUMBER" description:"The number to send the confirmation SMS to"`
+
+	PlayingPartners string `short:"s" long:"partners" env:"TS_PARTNERS" description:"A comma-separated list of other players to include in the booking"`
+
+	Notifier     string `short:"o" long:"notifier" default:"twilio" description:"Which notification backend(s) to use: twilio, ntfy, or both"`
+	NtfyTopicURL string `long:"ntfytopicurl" description:"The ntfy.sh (or self-hosted) topic URL to publish booking notifications to, required when notifier is ntfy or both"`
+
+	NotifyByCallIfWithin time.Duration `long:"notifybycallifwithin" default:"2h" description:"When a booking succeeds within this long of tee-off, also place a phone call via Twilio Voice in addition to the SMS/notifier"`
+
+	VerifyServiceSid      string `long:"verifyservicesid" description:"Twilio Verify Service SID; when set, SendSms refuses to message a number until it has completed opt-in verification"`
+	VerificationStorePath string `long:"verificationstorepath" default:"verification_store.json" description:"Path to the local file recording which numbers have completed opt-in verification"`
+
+	DailyLimitPerRecipient int    `long:"dailylimitperrecipient" default:"10" description:"The maximum number of notifications to send a single recipient per rolling 24h window"`
+	RateLimitStorePath     string `long:"ratelimitstorepath" default:"rate_limit_store.json" description:"Path to the local file tracking notifications sent per recipient for rate limiting"`
+	RateLimitBypass        bool   `long:"rate-limit-bypass" description:"Always deliver the booking-confirmation notification even if the recipient has been rate limited"`
+
+	BackendsConfigPath string `long:"backendsconfigpath" description:"Path to a YAML file listing multiple course/club backends to target; when unset, the Username/Pin/BaseUrl flags above are used as a single backend"`
+
+	BackoffStrategy   string  `long:"backoff-strategy" default:"decorrelated-jitter" description:"The retry backoff strategy used between retried booking steps: constant, linear, uniform-jitter, or decorrelated-jitter"`
+	BackoffMultiplier float64 `long:"backoff-multiplier" default:"3" description:"The growth factor decorrelated-jitter multiplies the previous delay by when computing the next one; ignored by the other strategies"`
+
+	NotifySinkTimeout time.Duration `long:"notifysinktimeout" default:"10s" description:"The maximum time to wait for each notification sink before giving up on it and moving to the next one"`
+
+	NotifierURLs []string `long:"notifier-url" description:"A notifier URL to send notifications through, e.g. twilio-sms://+123/+456, twilio-voice://+123/+456, slack-webhook://hooks.slack.com/..., or stdout://. May be repeated; when set, this replaces the Notifier/FromNumber/ToNumber flags above"`
+
+	LogLevel  string   `long:"log-level" default:"info" description:"The minimum log level to emit: debug, info, warn, or error"`
+	LogFormat string   `long:"log-format" default:"json" description:"The log encoding to emit: json (for Promtail/Loki), logfmt, or console"`
+	LogLabels []string `long:"log-labels" description:"A key=value label attached to every log line, e.g. course=stmarys. May be repeated"`
+
+	MetricsAddr string `long:"metrics-addr" description:"If set, start an HTTP server on this address serving /metrics (Prometheus) and /healthz, e.g. :9090"`
+
+	ConfigPath string `short:"c" long:"config" description:"Path to a YAML file defining one or more named profiles (base URL, credentials, target times, playing partners, notifier URLs); its values are layered under whatever is explicitly set via CLI flags or env vars"`
+	Profile    string `long:"profile" description:"The profile to load from --config; may be omitted when the file defines exactly one profile"`
+
+	ConfigFilePath string `long:"config-file" description:"Path to a YAML file listing several courses/clubs (base URL, credentials, time window, priority) to race concurrently for a tee time; when unset, the Username/Pin/BaseUrl/TimeStart/TimeEnd flags above are used as a single course"`
+
+	DaemonTriggerTime string `long:"daemon-trigger-time" default:"09:00" description:"The time of day (HH:MM, in DaemonTimezone) the 'daemon run' subcommand fires a booking attempt each day"`
+	DaemonTimezone    string `long:"daemon-timezone" default:"Local" description:"The IANA timezone 'daemon run' interprets DaemonTriggerTime in"`
+	RunStorePath      string `long:"runstorepath" default:"run_history.db" description:"Path to the BoltDB file 'daemon run' records its run history to, and the 'daemon list-runs'/'daemon skip-next'/'daemon trigger-now' admin commands read/write"`
+
+	CheckpointPath string `long:"checkpoint-path" description:"Path to a file the booking FSM persists its progress to after every transition; when set, a crashed run resumes from the last checkpointed state on next invocation instead of starting over from scratch"`
+
+	SessionPath string `long:"session-path" description:"Path to a file persisting the booking site's session cookies between runs; when set, a process restart reuses the stored session instead of logging in again if it's still valid"`
+
+	PreferredTime       string `long:"preferred-time" description:"A tee time (HH:MM) to prefer when more than one bookable slot passes timestart/timeend; the closest slot to this time wins"`
+	PreferredDaysOfWeek string `long:"preferred-days" description:"A comma-separated list of weekdays (mon, tue, wed, thu, fri, sat, sun) to weight favorably when scoring slots"`
+	PreferredCourseIDs  string `long:"preferred-courses" description:"A comma-separated list of course identifiers to weight favorably when scoring slots, for backends that expose more than one course"`
+}
+
+// requiredByFlag lists the Config fields that a --config profile is allowed
+// to supply instead of a CLI flag or env var, keyed by the flag's long name
+// for use in error messages. They aren't tagged required:"true" because
+// go-flags enforces that before a profile ever gets a chance to fill them
+// in - see GetConfig.
+var requiredByFlag = []struct {
+	long  string
+	unset func(c Config) bool
+}{
+	{"days", func(c Config) bool { return c.DaysAhead == 0 }},
+	{"timestart", func(c Config) bool { return c.TimeStart == "" }},
+	{"timeend", func(c Config) bool { return c.TimeEnd == "" }},
+	{"username", func(c Config) bool { return c.Username == "" }},
+	{"pin", func(c Config) bool { return c.Pin == "" }},
+	{"baseurl", func(c Config) bool { return c.BaseUrl == "" }},
+}
+
+// GetPlayingPartnersList splits PlayingPartners on commas, trimming
+// whitespace around each name, returning an empty (non-nil) slice when
+// PlayingPartners is unset.
+func (c Config) GetPlayingPartnersList() []string {
+	if strings.TrimSpace(c.PlayingPartners) == "" {
+		return []string{}
+	}
+
+	parts := strings.Split(c.PlayingPartners, ",")
+	partners := make([]string, len(parts))
+	for i, p := range parts {
+		partners[i] = strings.TrimSpace(p)
+	}
+	return partners
+}
+
+// GetPreferredCourseIDsList splits PreferredCourseIDs on commas, trimming
+// whitespace around each ID, returning an empty (non-nil) slice when
+// PreferredCourseIDs is unset.
+func (c Config) GetPreferredCourseIDsList() []string {
+	if strings.TrimSpace(c.PreferredCourseIDs) == "" {
+		return []string{}
+	}
+
+	parts := strings.Split(c.PreferredCourseIDs, ",")
+	ids := make([]string, len(parts))
+	for i, p := range parts {
+		ids[i] = strings.TrimSpace(p)
+	}
+	return ids
+}
+
+var weekdaysByName = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// GetPreferredDaysOfWeekList splits PreferredDaysOfWeek on commas into
+// time.Weekdays, silently skipping any entry that isn't a recognized
+// weekday abbreviation (mon, tue, wed, thu, fri, sat, sun) rather than
+// erroring, consistent with LogLabels' tolerance of malformed entries.
+func (c Config) GetPreferredDaysOfWeekList() []time.Weekday {
+	var days []time.Weekday
+	for _, part := range strings.Split(c.PreferredDaysOfWeek, ",") {
+		day, ok := weekdaysByName[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
 }
 
+// GetConfig parses the process's own os.Args into a Config. See
+// GetConfigFromArgs for parsing an explicit argument slice, e.g. a
+// subcommand's own args after its name has been stripped off.
 func GetConfig() (Config, error) {
+	return getConfig(func(p *flags.Parser) ([]string, error) { return p.Parse() })
+}
+
+// GetConfigFromArgs parses args (not os.Args) into a Config, so a
+// subcommand like `tee-sniper daemon run` can reuse the same flags,
+// env vars, and --config profile merging GetConfig gives the top-level
+// command.
+func GetConfigFromArgs(args []string) (Config, error) {
+	return getConfig(func(p *flags.Parser) ([]string, error) { return p.ParseArgs(args) })
+}
+
+func getConfig(parse func(*flags.Parser) ([]string, error)) (Config, error) {
 	var c Config
 	parser := flags.NewParser(&c, flags.Default)
 
-	_, err := parser.Parse()
+	_, err := parse(parser)
 	if err != nil {
 		if isErrHelp(err) {
 			return c, ErrHelp
@@ -37,9 +180,81 @@ func GetConfig() (Config, error) {
 		return c, err
 	}
 
+	if c.ConfigPath != "" {
+		fileConfig, err := LoadFileConfig(c.ConfigPath)
+		if err != nil {
+			return c, err
+		}
+
+		profile, err := fileConfig.Profile(c.Profile)
+		if err != nil {
+			return c, err
+		}
+
+		applyProfile(parser, &c, profile)
+	}
+
+	if err := validateRequired(c); err != nil {
+		return c, err
+	}
+
 	return c, nil
 }
 
+// applyProfile overlays p onto c, skipping any field whose corresponding
+// flag was already set via CLI or env var - CLI and env vars always win
+// over a --config profile, and a profile always wins over the hardcoded
+// flag defaults.
+func applyProfile(parser *flags.Parser, c *Config, p ProfileConfig) {
+	isSet := func(long string) bool {
+		opt := parser.FindOptionByLongName(long)
+		return opt != nil && opt.IsSet()
+	}
+
+	if p.BaseUrl != "" && !isSet("baseurl") {
+		c.BaseUrl = p.BaseUrl
+	}
+	if p.Username != "" && !isSet("username") {
+		c.Username = p.Username
+	}
+	if p.Pin != "" && !isSet("pin") {
+		c.Pin = p.Pin
+	}
+	if p.TimeStart != "" && !isSet("timestart") {
+		c.TimeStart = p.TimeStart
+	}
+	if p.TimeEnd != "" && !isSet("timeend") {
+		c.TimeEnd = p.TimeEnd
+	}
+	if p.DaysAhead != 0 && !isSet("days") {
+		c.DaysAhead = p.DaysAhead
+	}
+	if p.PlayingPartners != "" && !isSet("partners") {
+		c.PlayingPartners = p.PlayingPartners
+	}
+	if len(p.NotifierURLs) > 0 && !isSet("notifier-url") {
+		c.NotifierURLs = p.NotifierURLs
+	}
+}
+
+// validateRequired checks the fields listed in requiredByFlag are set by
+// the time CLI parsing and any --config profile have both had a chance to
+// supply them, mirroring the "required flags were not specified" error
+// go-flags itself would have produced had these still been tagged
+// required:"true".
+func validateRequired(c Config) error {
+	var missing []string
+	for _, f := range requiredByFlag {
+		if f.unset(c) {
+			missing = append(missing, "--"+f.long)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the required flags %s were not specified", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func isErrHelp(err error) bool {
 	flagsErr, ok := err.(*flags.Error)
 	if ok {
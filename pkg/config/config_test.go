@@ -3,10 +3,12 @@ package config
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	flags "github.com/jessevdk/go-flags"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetPlayingPartnersList(t *testing.T) {
@@ -320,6 +322,128 @@ func TestGetConfigCliArgsOverrideEnvVars(t *testing.T) {
 	assert.Equal(t, "envuser", cfg.Username, "Env var should be used when CLI arg not provided")
 }
 
+func TestGetConfigProfileFillsUnsetFields(t *testing.T) {
+	// Save original os.Args and restore after test
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profiles.yaml")
+	yaml := `
+profiles:
+  - name: club-a
+    base_url: https://club-a.example.com
+    username: profileuser
+    pin: "9999"
+    time_start: "07:00"
+    time_end: "11:00"
+    days_ahead: 5
+`
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+
+	os.Args = []string{
+		"cmd",
+		"-c", path,
+		"-r", "3",
+		"-f", "+1234567890",
+		"-n", "+0987654321",
+	}
+
+	cfg, err := GetConfig()
+	require.NoError(t, err, "GetConfig should succeed when a profile supplies the otherwise-missing fields")
+	assert.Equal(t, "https://club-a.example.com", cfg.BaseUrl)
+	assert.Equal(t, "profileuser", cfg.Username)
+	assert.Equal(t, "9999", cfg.Pin)
+	assert.Equal(t, "07:00", cfg.TimeStart)
+	assert.Equal(t, "11:00", cfg.TimeEnd)
+	assert.Equal(t, 5, cfg.DaysAhead)
+}
+
+func TestGetConfigCliArgsOverrideProfile(t *testing.T) {
+	// Save original os.Args and restore after test
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profiles.yaml")
+	yaml := `
+profiles:
+  - name: club-a
+    base_url: https://club-a.example.com
+    username: profileuser
+    pin: "9999"
+    time_start: "07:00"
+    time_end: "11:00"
+    days_ahead: 5
+`
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+
+	os.Args = []string{
+		"cmd",
+		"-c", path,
+		"-d", "7",
+		"-u", "cliuser",
+		"-r", "3",
+		"-f", "+1234567890",
+		"-n", "+0987654321",
+	}
+
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+
+	// CLI args should override the profile
+	assert.Equal(t, 7, cfg.DaysAhead, "CLI arg should override profile value")
+	assert.Equal(t, "cliuser", cfg.Username, "CLI arg should override profile value")
+
+	// Profile should be used when CLI arg not provided
+	assert.Equal(t, "07:00", cfg.TimeStart, "Profile value should be used when CLI arg not provided")
+	assert.Equal(t, "https://club-a.example.com", cfg.BaseUrl, "Profile value should be used when CLI arg not provided")
+}
+
+func TestGetConfigMultipleProfilesRequiresProfileFlag(t *testing.T) {
+	// Save original os.Args and restore after test
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profiles.yaml")
+	yaml := `
+profiles:
+  - name: club-a
+    base_url: https://club-a.example.com
+    username: usera
+    pin: "1111"
+    time_start: "07:00"
+    time_end: "11:00"
+    days_ahead: 5
+  - name: club-b
+    base_url: https://club-b.example.com
+    username: userb
+    pin: "2222"
+    time_start: "08:00"
+    time_end: "12:00"
+    days_ahead: 6
+`
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+
+	os.Args = []string{
+		"cmd",
+		"-c", path,
+		"-r", "3",
+		"-f", "+1234567890",
+		"-n", "+0987654321",
+	}
+
+	_, err := GetConfig()
+	assert.Error(t, err, "GetConfig should error when the config file defines more than one profile and --profile is unset")
+
+	os.Args = append(os.Args, "--profile", "club-b")
+	cfg, err := GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://club-b.example.com", cfg.BaseUrl)
+	assert.Equal(t, "userb", cfg.Username)
+}
+
 func TestGetConfigDryRunEnvVar(t *testing.T) {
 	// Save original os.Args and restore after test
 	originalArgs := os.Args
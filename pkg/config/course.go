@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CourseConfig is one course/club App.RunCourses can race against, loaded
+// from a --config-file YAML document. Unlike bookingpool.BackendConfig
+// (which shares one TimeStart/TimeEnd/PlayingPartners across every
+// backend and tries them sequentially with failover), each CourseConfig
+// carries its own preferred time window, and all of them are attempted
+// concurrently - see App.RunCourses.
+type CourseConfig struct {
+	Name      string `yaml:"name"`
+	BaseUrl   string `yaml:"base_url"`
+	Username  string `yaml:"username"`
+	Pin       string `yaml:"pin"`
+	TimeStart string `yaml:"time_start"`
+	TimeEnd   string `yaml:"time_end"`
+
+	// SessionPath, when set, persists this course's login cookies to disk
+	// between runs so a process restart can reuse the session instead of
+	// logging in again if it's still valid.
+	SessionPath string `yaml:"session_path"`
+
+	// Priority is read from the file, but App.RunCourses does not use it:
+	// every course is raced with no preference between them, and when more
+	// than one course books, every booking stands.
+	Priority int `yaml:"priority"`
+}
+
+// LoadCourseConfigs reads a YAML file containing a list of courses.
+func LoadCourseConfigs(path string) ([]CourseConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var courses []CourseConfig
+	if err := yaml.Unmarshal(data, &courses); err != nil {
+		return nil, fmt.Errorf("config: failed to parse %s: %w", path, err)
+	}
+	return courses, nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig is the shape of a --config YAML file: a named list of
+// profiles, one per course/club a user wants to snipe tee times at.
+type FileConfig struct {
+	Profiles []ProfileConfig `yaml:"profiles"`
+}
+
+// ProfileConfig is one named profile's settings from a --config file. A
+// profile's fields are layered under whatever CLI args and env vars were
+// explicitly set, and over the flag defaults - see applyProfile.
+type ProfileConfig struct {
+	Name            string   `yaml:"name"`
+	BaseUrl         string   `yaml:"base_url"`
+	Username        string   `yaml:"username"`
+	Pin             string   `yaml:"pin"`
+	TimeStart       string   `yaml:"time_start"`
+	TimeEnd         string   `yaml:"time_end"`
+	DaysAhead       int      `yaml:"days_ahead"`
+	PlayingPartners string   `yaml:"playing_partners"`
+	NotifierURLs    []string `yaml:"notifier_urls"`
+}
+
+// LoadFileConfig reads a YAML file containing a list of named profiles.
+func LoadFileConfig(path string) (FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileConfig{}, err
+	}
+
+	var fc FileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return FileConfig{}, fmt.Errorf("config: failed to parse %s: %w", path, err)
+	}
+	return fc, nil
+}
+
+// Profile returns the named profile, or the file's only profile when name
+// is empty and exactly one is defined. It errors if name doesn't match any
+// profile, or if name is empty and the file defines more than one.
+func (f FileConfig) Profile(name string) (ProfileConfig, error) {
+	if name == "" {
+		switch len(f.Profiles) {
+		case 0:
+			return ProfileConfig{}, fmt.Errorf("config: --config file defines no profiles")
+		case 1:
+			return f.Profiles[0], nil
+		default:
+			return ProfileConfig{}, fmt.Errorf("config: --config file defines more than one profile; specify --profile")
+		}
+	}
+
+	for _, p := range f.Profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return ProfileConfig{}, fmt.Errorf("config: --config file has no profile named %q", name)
+}
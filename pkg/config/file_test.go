@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFileConfigParsesProfileList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	yaml := `
+profiles:
+  - name: club-a
+    base_url: https://club-a.example.com
+    username: user-a
+    pin: "1111"
+    time_start: "07:00"
+    time_end: "11:00"
+    days_ahead: 5
+    playing_partners: p1,p2
+    notifier_urls:
+      - stdout://
+  - name: club-b
+    base_url: https://club-b.example.com
+    username: user-b
+    pin: "2222"
+`
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0600))
+
+	fc, err := LoadFileConfig(path)
+
+	require.NoError(t, err)
+	require.Len(t, fc.Profiles, 2)
+	assert.Equal(t, "club-a", fc.Profiles[0].Name)
+	assert.Equal(t, 5, fc.Profiles[0].DaysAhead)
+	assert.Equal(t, []string{"stdout://"}, fc.Profiles[0].NotifierURLs)
+	assert.Equal(t, "club-b", fc.Profiles[1].Name)
+}
+
+func TestLoadFileConfigMissingFileReturnsError(t *testing.T) {
+	_, err := LoadFileConfig(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	assert.Error(t, err)
+}
+
+func TestFileConfigProfileReturnsOnlyProfileWhenNameEmpty(t *testing.T) {
+	fc := FileConfig{Profiles: []ProfileConfig{{Name: "club-a"}}}
+
+	p, err := fc.Profile("")
+
+	require.NoError(t, err)
+	assert.Equal(t, "club-a", p.Name)
+}
+
+func TestFileConfigProfileErrorsWhenNameEmptyAndMultipleProfiles(t *testing.T) {
+	fc := FileConfig{Profiles: []ProfileConfig{{Name: "club-a"}, {Name: "club-b"}}}
+
+	_, err := fc.Profile("")
+
+	assert.Error(t, err)
+}
+
+func TestFileConfigProfileErrorsWhenNameEmptyAndNoProfiles(t *testing.T) {
+	_, err := FileConfig{}.Profile("")
+	assert.Error(t, err)
+}
+
+func TestFileConfigProfileSelectsByName(t *testing.T) {
+	fc := FileConfig{Profiles: []ProfileConfig{{Name: "club-a"}, {Name: "club-b"}}}
+
+	p, err := fc.Profile("club-b")
+
+	require.NoError(t, err)
+	assert.Equal(t, "club-b", p.Name)
+}
+
+func TestFileConfigProfileErrorsForUnknownName(t *testing.T) {
+	fc := FileConfig{Profiles: []ProfileConfig{{Name: "club-a"}}}
+
+	_, err := fc.Profile("club-z")
+
+	assert.Error(t, err)
+}
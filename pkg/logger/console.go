@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// consoleHandler is a slog.Handler for interactive terminal use: just the
+// level, message, and attributes on one line, with no timestamp or JSON/
+// logfmt escaping - for local development, where json and logfmt are meant
+// for Promtail/Loki rather than a human reading a terminal.
+type consoleHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	opts   *slog.HandlerOptions
+	attrs  []slog.Attr
+	groups []string
+}
+
+func newConsoleHandler(w io.Writer, opts *slog.HandlerOptions) *consoleHandler {
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	return &consoleHandler{mu: &sync.Mutex{}, w: w, opts: opts}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	line := fmt.Sprintf("%-5s %s", r.Level, r.Message)
+	for _, a := range h.attrs {
+		line += fmt.Sprintf(" %s=%v", a.Key, a.Value)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		line += fmt.Sprintf(" %s=%v", a.Key, a.Value)
+		return true
+	})
+
+	_, err := fmt.Fprintln(h.w, line)
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{
+		mu:     h.mu,
+		w:      h.w,
+		opts:   h.opts,
+		attrs:  append(append([]slog.Attr{}, h.attrs...), attrs...),
+		groups: h.groups,
+	}
+}
+
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	return &consoleHandler{
+		mu:     h.mu,
+		w:      h.w,
+		opts:   h.opts,
+		attrs:  h.attrs,
+		groups: append(append([]string{}, h.groups...), name),
+	}
+}
+
+var _ slog.Handler = (*consoleHandler)(nil)
@@ -6,25 +6,48 @@ import (
 	"strings"
 )
 
-// Init initializes the global logger with JSON output for Loki compatibility.
-// Should be called once at application startup.
-func Init(level string) {
+// Init initializes the global logger for Loki compatibility. format selects
+// the output encoding ("json", "logfmt", or "console" - anything else falls
+// back to "json"); labels are repeatable "key=value" pairs (e.g. from
+// Config.LogLabels) attached to every log line, so operators running under
+// Promtail/Loki can slice logs by course, member, etc. Should be called once
+// at application startup.
+func Init(level, format string, labels []string) {
 	slogLevel := parseLogLevel(level)
 
 	opts := &slog.HandlerOptions{
 		Level: slogLevel,
 	}
 
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	handler := newHandler(format, opts)
 
 	// Add default application attributes
 	logger := slog.New(handler).With(
 		slog.String("app", "tee-sniper"),
 	)
 
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			continue
+		}
+		logger = logger.With(slog.String(key, value))
+	}
+
 	slog.SetDefault(logger)
 }
 
+func newHandler(format string, opts *slog.HandlerOptions) slog.Handler {
+	switch strings.ToLower(format) {
+	case "logfmt":
+		return slog.NewTextHandler(os.Stdout, opts)
+	case "console":
+		return newConsoleHandler(os.Stdout, opts)
+	default:
+		return slog.NewJSONHandler(os.Stdout, opts)
+	}
+}
+
 func parseLogLevel(levelStr string) slog.Level {
 	switch strings.ToLower(levelStr) {
 	case "debug":
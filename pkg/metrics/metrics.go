@@ -0,0 +1,94 @@
+// Package metrics exposes the Prometheus instruments for running
+// tee-sniper as a long-lived daemon: booking outcomes and notifier send
+// outcomes, all under the "teesniper" namespace.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds every collector this package exposes. It implements
+// prometheus.Collector itself, so a single call to registry.MustRegister(m)
+// registers everything.
+type Metrics struct {
+	bookingAttempts   *prometheus.CounterVec
+	bookingDuration   *prometheus.HistogramVec
+	retryDelay        prometheus.Histogram
+	notifierSendTotal *prometheus.CounterVec
+}
+
+// New creates a Metrics with all collectors initialized. Register the
+// result with a prometheus.Registerer before use.
+func New() *Metrics {
+	return &Metrics{
+		bookingAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "teesniper_booking_attempts_total",
+			Help: "Total number of booking attempts, labeled by outcome.",
+		}, []string{"outcome"}),
+		bookingDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "teesniper_booking_duration_seconds",
+			Help:    "Duration of a full booking run (login through book, across retries) in seconds, labeled by outcome.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		retryDelay: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "teesniper_retry_delay_seconds",
+			Help:    "Backoff delay slept before each in-place booking retry, in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		notifierSendTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "teesniper_notifier_send_total",
+			Help: "Total number of notifier sends, labeled by backend and outcome.",
+		}, []string{"backend", "outcome"}),
+	}
+}
+
+// BookingAttempt records a booking attempt with the given outcome, e.g.
+// "booked", "unavailable", or "error".
+func (m *Metrics) BookingAttempt(outcome string) {
+	m.bookingAttempts.WithLabelValues(outcome).Inc()
+}
+
+// BookingDuration records how long a full booking run (login through book,
+// across retries) took, against the same outcome labels as BookingAttempt.
+func (m *Metrics) BookingDuration(outcome string, duration time.Duration) {
+	m.bookingDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// RetryDelay records the backoff delay slept before an in-place booking
+// retry - see bookingfsm.Context.OnRetry.
+func (m *Metrics) RetryDelay(delay time.Duration) {
+	m.retryDelay.Observe(delay.Seconds())
+}
+
+// NotifierSend records a notifier send against backend with the given
+// outcome, e.g. "ok" or "error".
+func (m *Metrics) NotifierSend(backend, outcome string) {
+	m.notifierSendTotal.WithLabelValues(backend, outcome).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.bookingAttempts,
+		m.bookingDuration,
+		m.retryDelay,
+		m.notifierSendTotal,
+	}
+}
+
+var _ prometheus.Collector = (*Metrics)(nil)
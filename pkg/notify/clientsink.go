@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"text/template"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+// ClientsNotifierSink adapts an existing clients.Notifier - e.g. the
+// Twilio SMS, ntfy, or rate-limited/verify-gated notifiers already built
+// in pkg/clients - into a NotificationSink, rendering Event through a
+// template before sending. This is how existing notifier configuration
+// keeps working as sinks: wrap it once, here.
+type ClientsNotifierSink struct {
+	Notifier  clients.Notifier
+	Recipient string
+	Priority  clients.Priority
+	DryRun    bool
+
+	// Templates overrides the default message template per EventKind.
+	Templates map[EventKind]*template.Template
+}
+
+// Notify implements NotificationSink.
+func (s ClientsNotifierSink) Notify(ctx context.Context, event Event) error {
+	body, err := RenderTemplate(templateOrDefault(s.Templates, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	return s.Notifier.Notify(ctx, s.Recipient, string(event.Kind), body, s.Priority, s.DryRun)
+}
+
+var _ NotificationSink = ClientsNotifierSink{}
@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	recipient, subject, body string
+	priority                 clients.Priority
+	dryRun                   bool
+	err                      error
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, recipient, subject, body string, priority clients.Priority, dryRun bool) error {
+	f.recipient = recipient
+	f.subject = subject
+	f.body = body
+	f.priority = priority
+	f.dryRun = dryRun
+	return f.err
+}
+
+func TestClientsNotifierSinkRendersEventAndDelegates(t *testing.T) {
+	notifier := &fakeNotifier{}
+	sink := ClientsNotifierSink{Notifier: notifier, Recipient: "+123", Priority: clients.PriorityHigh, DryRun: true}
+
+	err := sink.Notify(context.Background(), Event{Kind: EventBooked, SlotTime: "10:00", Date: "22-01-2024", PartySize: 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, "+123", notifier.recipient)
+	assert.Equal(t, "booked", notifier.subject)
+	assert.Equal(t, "Successfully booked tee time: 10:00 on 22-01-2024 for 4 people", notifier.body)
+	assert.Equal(t, clients.PriorityHigh, notifier.priority)
+	assert.True(t, notifier.dryRun)
+}
+
+func TestClientsNotifierSinkReturnsNotifierError(t *testing.T) {
+	notifier := &fakeNotifier{err: errors.New("boom")}
+	sink := ClientsNotifierSink{Notifier: notifier, Recipient: "+123"}
+
+	err := sink.Notify(context.Background(), Event{Kind: EventBooked})
+
+	assert.ErrorIs(t, err, notifier.err)
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"text/template"
+)
+
+// discordMessage is the minimal Discord incoming-webhook payload shape.
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+// DiscordWebhookSink posts the rendered Event as a message to a Discord
+// incoming webhook URL.
+type DiscordWebhookSink struct {
+	httpClient *http.Client
+	webhookURL string
+
+	// Templates overrides the default message template per EventKind.
+	Templates map[EventKind]*template.Template
+}
+
+// NewDiscordWebhookSink creates a DiscordWebhookSink that posts to webhookURL.
+func NewDiscordWebhookSink(webhookURL string) *DiscordWebhookSink {
+	return &DiscordWebhookSink{httpClient: &http.Client{}, webhookURL: webhookURL}
+}
+
+// Notify implements NotificationSink.
+func (s DiscordWebhookSink) Notify(ctx context.Context, event Event) error {
+	content, err := RenderTemplate(templateOrDefault(s.Templates, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(discordMessage{Content: content})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("discord: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+var _ NotificationSink = (*DiscordWebhookSink)(nil)
+
+// httpNotifierURL turns a notifier URL such as "discord-webhook://discord.com/api/..."
+// back into the plain https:// URL the underlying webhook expects - the
+// notifier scheme exists only to route to the right Factory.
+func httpNotifierURL(u *url.URL) string {
+	plain := *u
+	plain.Scheme = "https"
+	return plain.String()
+}
+
+func init() {
+	Register("discord-webhook", func(u *url.URL) (NotificationSink, error) {
+		return NewDiscordWebhookSink(httpNotifierURL(u)), nil
+	})
+
+	Register("slack-webhook", func(u *url.URL) (NotificationSink, error) {
+		return NewSlackSink(httpNotifierURL(u)), nil
+	})
+
+	Register("generic-webhook", func(u *url.URL) (NotificationSink, error) {
+		return NewWebhookSink(httpNotifierURL(u)), nil
+	})
+
+	Register("stdout", func(u *url.URL) (NotificationSink, error) {
+		return LogSink{}, nil
+	})
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscordWebhookSinkPostsRenderedContent(t *testing.T) {
+	var got discordMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewDiscordWebhookSink(server.URL)
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled, BookingID: "abc", Date: "22-01-2024"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Booking abc on 22-01-2024 was cancelled", got.Content)
+}
+
+func TestDiscordWebhookSinkNonOKStatusIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sink := NewDiscordWebhookSink(server.URL)
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled})
+
+	assert.Error(t, err)
+}
+
+func TestHTTPNotifierURLRewritesSchemeToHTTPS(t *testing.T) {
+	u, err := url.Parse("discord-webhook://discord.com/api/webhooks/1/abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://discord.com/api/webhooks/1/abc", httpNotifierURL(u))
+}
+
+func TestBuildURLRoutesKnownSchemesToExpectedSinkTypes(t *testing.T) {
+	sink, err := BuildURL("stdout://anything")
+	require.NoError(t, err)
+	assert.IsType(t, LogSink{}, sink)
+
+	sink, err = BuildURL("slack-webhook://hooks.slack.com/services/abc")
+	require.NoError(t, err)
+	assert.IsType(t, &SlackSink{}, sink)
+
+	sink, err = BuildURL("discord-webhook://discord.com/api/webhooks/1/abc")
+	require.NoError(t, err)
+	assert.IsType(t, &DiscordWebhookSink{}, sink)
+
+	sink, err = BuildURL("generic-webhook://example.com/hook")
+	require.NoError(t, err)
+	assert.IsType(t, &WebhookSink{}, sink)
+}
@@ -0,0 +1,31 @@
+// Package notify generalizes clients.Notifier into a typed NotificationSink
+// contract, so booking outcomes are expressed as a structured Event rather
+// than a hardcoded message string, and a sink renders its own notification
+// body from that Event via a text/template. ClientsNotifierSink adapts the
+// existing clients.Notifier backends (Twilio SMS, ntfy, rate limiting) into
+// a NotificationSink, so this layers on top of pkg/clients rather than
+// replacing it; SMTPSink, SlackSink, and WebhookSink are new backends that
+// implement NotificationSink directly.
+package notify
+
+// EventKind identifies what happened to a booking attempt.
+type EventKind string
+
+const (
+	EventBooked        EventKind = "booked"
+	EventBookingFailed EventKind = "booking_failed"
+	EventCancelled     EventKind = "cancelled"
+)
+
+// Event carries the typed fields a NotificationSink needs to render a
+// notification, replacing the hardcoded message strings App used to build
+// itself.
+type Event struct {
+	Kind      EventKind
+	Date      string
+	SlotTime  string
+	BookingID string
+	PartySize int
+	Partners  []string
+	Err       string
+}
@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// FanOut delivers event to every sink, each under its own perSinkTimeout so
+// one slow or failing sink can't block or fail the others. Errors - and
+// panics, so a single misbehaving sink can't take the others down with it -
+// are logged, not returned or aggregated: notification delivery is always
+// best-effort and must never fail the booking attempt that triggered it.
+func FanOut(ctx context.Context, sinks []NotificationSink, event Event, perSinkTimeout time.Duration) {
+	for _, sink := range sinks {
+		notifyOne(ctx, sink, event, perSinkTimeout)
+	}
+}
+
+func notifyOne(ctx context.Context, sink NotificationSink, event Event, perSinkTimeout time.Duration) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("notify: sink panicked delivering %s event: %v", event.Kind, r)
+		}
+	}()
+
+	sinkCtx, cancel := context.WithTimeout(ctx, perSinkTimeout)
+	defer cancel()
+
+	if err := sink.Notify(sinkCtx, event); err != nil {
+		log.Printf("notify: sink failed to deliver %s event: %v", event.Kind, err)
+	}
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSink struct {
+	events []Event
+	err    error
+}
+
+func (s *recordingSink) Notify(ctx context.Context, event Event) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func TestFanOutDeliversToEverySink(t *testing.T) {
+	a := &recordingSink{}
+	b := &recordingSink{}
+
+	FanOut(context.Background(), []NotificationSink{a, b}, Event{Kind: EventBooked}, time.Second)
+
+	assert.Len(t, a.events, 1)
+	assert.Len(t, b.events, 1)
+}
+
+func TestFanOutContinuesPastFailingSink(t *testing.T) {
+	failing := &recordingSink{err: errors.New("boom")}
+	ok := &recordingSink{}
+
+	FanOut(context.Background(), []NotificationSink{failing, ok}, Event{Kind: EventBooked}, time.Second)
+
+	assert.Len(t, failing.events, 1)
+	assert.Len(t, ok.events, 1)
+}
+
+type panickingSink struct{}
+
+func (s *panickingSink) Notify(ctx context.Context, event Event) error {
+	panic("boom")
+}
+
+func TestFanOutContinuesPastPanickingSink(t *testing.T) {
+	ok := &recordingSink{}
+
+	assert.NotPanics(t, func() {
+		FanOut(context.Background(), []NotificationSink{&panickingSink{}, ok}, Event{Kind: EventBooked}, time.Second)
+	})
+
+	assert.Len(t, ok.events, 1)
+}
@@ -0,0 +1,32 @@
+package notify
+
+import "context"
+
+// SendRecorder receives the subset of pkg/metrics.Metrics InstrumentedSink
+// needs, so this package doesn't have to import pkg/metrics directly.
+type SendRecorder interface {
+	NotifierSend(backend, outcome string)
+}
+
+// InstrumentedSink wraps a NotificationSink, recording each send against
+// Backend with outcome "ok" or "error" via Metrics.
+type InstrumentedSink struct {
+	Sink    NotificationSink
+	Backend string
+	Metrics SendRecorder
+}
+
+// Notify implements NotificationSink.
+func (s InstrumentedSink) Notify(ctx context.Context, event Event) error {
+	err := s.Sink.Notify(ctx, event)
+
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	s.Metrics.NotifierSend(s.Backend, outcome)
+
+	return err
+}
+
+var _ NotificationSink = InstrumentedSink{}
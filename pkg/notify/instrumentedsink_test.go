@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSendRecorder struct {
+	backend string
+	outcome string
+	calls   int
+}
+
+func (f *fakeSendRecorder) NotifierSend(backend, outcome string) {
+	f.backend = backend
+	f.outcome = outcome
+	f.calls++
+}
+
+type erroringSink struct {
+	err error
+}
+
+func (s erroringSink) Notify(ctx context.Context, event Event) error {
+	return s.err
+}
+
+func TestInstrumentedSinkRecordsOkOnSuccess(t *testing.T) {
+	recorder := &fakeSendRecorder{}
+	sink := InstrumentedSink{Sink: LogSink{}, Backend: "stdout", Metrics: recorder}
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, recorder.calls)
+	assert.Equal(t, "stdout", recorder.backend)
+	assert.Equal(t, "ok", recorder.outcome)
+}
+
+func TestInstrumentedSinkRecordsErrorOnFailure(t *testing.T) {
+	recorder := &fakeSendRecorder{}
+	sink := InstrumentedSink{Sink: erroringSink{err: errors.New("boom")}, Backend: "webhook", Metrics: recorder}
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled})
+
+	assert.Error(t, err)
+	assert.Equal(t, "error", recorder.outcome)
+}
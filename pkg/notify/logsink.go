@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+// LogSink renders the Event and writes it to the standard logger, for
+// local runs or as a fallback when no real sink is configured.
+type LogSink struct{}
+
+// Notify implements NotificationSink.
+func (LogSink) Notify(ctx context.Context, event Event) error {
+	body, err := RenderTemplate(templateOrDefault(nil, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("notify: %s", body)
+	return nil
+}
+
+var _ NotificationSink = LogSink{}
@@ -0,0 +1,16 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogSinkNeverErrors(t *testing.T) {
+	sink := LogSink{}
+
+	err := sink.Notify(context.Background(), Event{Kind: EventBooked, SlotTime: "10:00", Date: "22-01-2024", PartySize: 4})
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Factory builds a NotificationSink from a parsed notifier URL, e.g.
+// "twilio-sms://+123/+456" or "slack-webhook://hooks.slack.com/...".
+type Factory func(u *url.URL) (NotificationSink, error)
+
+// registry maps a notifier URL's scheme to the Factory that builds it.
+// Populated by each backend file's init via Register, so adding a new
+// channel is a new file, not an edit to this one.
+var registry = map[string]Factory{}
+
+// Register adds factory under scheme, so BuildURL can construct a sink from
+// a "scheme://..." notifier URL. Intended to be called from a backend
+// file's init.
+func Register(scheme string, factory Factory) {
+	registry[scheme] = factory
+}
+
+// BuildURL parses raw as a notifier URL and builds the sink registered for
+// its scheme.
+func BuildURL(raw string) (NotificationSink, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	factory, ok := registry[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("notify: no notifier registered for scheme %q", u.Scheme)
+	}
+
+	return factory(u)
+}
+
+// BuildAll builds a sink for every URL in raws - see BuildURL.
+func BuildAll(raws []string) ([]NotificationSink, error) {
+	sinks := make([]NotificationSink, 0, len(raws))
+	for _, raw := range raws {
+		sink, err := BuildURL(raw)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
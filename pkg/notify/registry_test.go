@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildURLUsesRegisteredFactory(t *testing.T) {
+	Register("fake-scheme-for-test", func(u *url.URL) (NotificationSink, error) {
+		return LogSink{}, nil
+	})
+
+	sink, err := BuildURL("fake-scheme-for-test://anything")
+
+	require.NoError(t, err)
+	assert.Equal(t, LogSink{}, sink)
+}
+
+func TestBuildURLUnknownSchemeReturnsError(t *testing.T) {
+	_, err := BuildURL("no-such-scheme://anything")
+
+	assert.Error(t, err)
+}
+
+func TestBuildAllBuildsEverySink(t *testing.T) {
+	calls := 0
+	Register("fake-scheme-for-buildall", func(u *url.URL) (NotificationSink, error) {
+		calls++
+		return LogSink{}, nil
+	})
+
+	sinks, err := BuildAll([]string{"fake-scheme-for-buildall://a", "fake-scheme-for-buildall://b"})
+
+	require.NoError(t, err)
+	assert.Len(t, sinks, 2)
+	assert.Equal(t, 2, calls)
+}
+
+func TestBuildAllStopsOnFirstError(t *testing.T) {
+	_, err := BuildAll([]string{"no-such-scheme-either://a"})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,11 @@
+package notify
+
+import "context"
+
+// NotificationSink receives a typed Event for every booking outcome.
+// Implementations render their own message from the Event (see
+// RenderTemplate) and are expected to isolate their own failures - a
+// failing sink should never stop the rest of a fan-out from delivering.
+type NotificationSink interface {
+	Notify(ctx context.Context, event Event) error
+}
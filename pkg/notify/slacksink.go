@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+)
+
+// slackMessage is the minimal Slack incoming-webhook payload shape.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// SlackSink posts the rendered Event as a message to a Slack incoming
+// webhook URL.
+type SlackSink struct {
+	httpClient *http.Client
+	webhookURL string
+
+	// Templates overrides the default message template per EventKind.
+	Templates map[EventKind]*template.Template
+}
+
+// NewSlackSink creates a SlackSink that posts to webhookURL.
+func NewSlackSink(webhookURL string) *SlackSink {
+	return &SlackSink{httpClient: &http.Client{}, webhookURL: webhookURL}
+}
+
+// Notify implements NotificationSink.
+func (s SlackSink) Notify(ctx context.Context, event Event) error {
+	text, err := RenderTemplate(templateOrDefault(s.Templates, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(slackMessage{Text: text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("slack: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+var _ NotificationSink = (*SlackSink)(nil)
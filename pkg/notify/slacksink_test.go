@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackSinkPostsRenderedText(t *testing.T) {
+	var got slackMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewSlackSink(server.URL)
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled, BookingID: "abc", Date: "22-01-2024"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Booking abc on 22-01-2024 was cancelled", got.Text)
+}
+
+func TestSlackSinkNonOKStatusIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sink := NewSlackSink(server.URL)
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled})
+
+	assert.Error(t, err)
+}
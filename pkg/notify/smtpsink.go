@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// SMTPSink emails the rendered Event via an SMTP relay. sendMail is an
+// unexported field rather than a free function so tests can substitute a
+// fake without talking to a real SMTP server - there's no net/smtp
+// equivalent of httptest.NewServer.
+type SMTPSink struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+
+	// Templates overrides the default message template per EventKind.
+	Templates map[EventKind]*template.Template
+
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPSink creates an SMTPSink that relays through addr (host:port),
+// authenticating with auth, from the From address to each address in to.
+func NewSMTPSink(addr string, auth smtp.Auth, from string, to []string) *SMTPSink {
+	return &SMTPSink{
+		Addr:     addr,
+		Auth:     auth,
+		From:     from,
+		To:       to,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Notify implements NotificationSink.
+func (s SMTPSink) Notify(ctx context.Context, event Event) error {
+	body, err := RenderTemplate(templateOrDefault(s.Templates, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Subject: %s\r\n\r\n%s\r\n", event.Kind, body)
+
+	sendMail := s.sendMail
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+
+	return sendMail(s.Addr, s.Auth, s.From, s.To, []byte(msg))
+}
+
+var _ NotificationSink = (*SMTPSink)(nil)
+
+// smtpAuthFromURL returns PLAIN auth for u's userinfo, or nil when u has
+// none - an open relay needs no Auth.
+func smtpAuthFromURL(u *url.URL) smtp.Auth {
+	if u.User == nil {
+		return nil
+	}
+
+	password, _ := u.User.Password()
+	host := strings.Split(u.Host, ":")[0]
+	return smtp.PlainAuth("", u.User.Username(), password, host)
+}
+
+func init() {
+	Register("smtp", func(u *url.URL) (NotificationSink, error) {
+		q := u.Query()
+
+		from := q.Get("from")
+		if from == "" {
+			return nil, fmt.Errorf("notify: smtp notifier URL %q is missing a from query parameter", u.Redacted())
+		}
+
+		to := q.Get("to")
+		if to == "" {
+			return nil, fmt.Errorf("notify: smtp notifier URL %q is missing a to query parameter", u.Redacted())
+		}
+
+		return NewSMTPSink(u.Host, smtpAuthFromURL(u), from, strings.Split(to, ",")), nil
+	})
+}
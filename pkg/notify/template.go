@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// defaultTemplateSource is the text/template body used for each EventKind
+// when a sink doesn't set its own Templates override.
+var defaultTemplateSource = map[EventKind]string{
+	EventBooked:        "Successfully booked tee time: {{.SlotTime}} on {{.Date}} for {{.PartySize}} people",
+	EventBookingFailed: "Failed to book tee time on {{.Date}}: {{.Err}}",
+	EventCancelled:     "Booking {{.BookingID}} on {{.Date}} was cancelled",
+}
+
+// defaultTemplateFor returns the built-in template for kind, parsed fresh
+// each call since these are only used as a fallback, not on a hot path.
+func defaultTemplateFor(kind EventKind) *template.Template {
+	src, ok := defaultTemplateSource[kind]
+	if !ok {
+		src = "{{.Kind}}: {{.Date}} {{.SlotTime}}"
+	}
+	return template.Must(template.New(string(kind)).Parse(src))
+}
+
+// templateOrDefault returns templates[event.Kind] if set, else the
+// built-in default for that kind.
+func templateOrDefault(templates map[EventKind]*template.Template, kind EventKind) *template.Template {
+	if tmpl, ok := templates[kind]; ok {
+		return tmpl
+	}
+	return defaultTemplateFor(kind)
+}
+
+// RenderTemplate executes tmpl with event as its data.
+func RenderTemplate(tmpl *template.Template, event Event) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplateUsesDefaultForBooked(t *testing.T) {
+	body, err := RenderTemplate(defaultTemplateFor(EventBooked), Event{
+		Kind: EventBooked, Date: "22-01-2024", SlotTime: "10:00", PartySize: 4,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Successfully booked tee time: 10:00 on 22-01-2024 for 4 people", body)
+}
+
+func TestRenderTemplateUsesDefaultForBookingFailed(t *testing.T) {
+	body, err := RenderTemplate(defaultTemplateFor(EventBookingFailed), Event{
+		Kind: EventBookingFailed, Date: "22-01-2024", Err: "no slots available",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Failed to book tee time on 22-01-2024: no slots available", body)
+}
+
+func TestTemplateOrDefaultPrefersOverride(t *testing.T) {
+	override := template.Must(template.New("x").Parse("custom: {{.Date}}"))
+	templates := map[EventKind]*template.Template{EventBooked: override}
+
+	got := templateOrDefault(templates, EventBooked)
+
+	body, err := RenderTemplate(got, Event{Date: "22-01-2024"})
+	assert.NoError(t, err)
+	assert.Equal(t, "custom: 22-01-2024", body)
+}
+
+func TestTemplateOrDefaultFallsBackWhenNoOverride(t *testing.T) {
+	got := templateOrDefault(nil, EventCancelled)
+
+	body, err := RenderTemplate(got, Event{Kind: EventCancelled, BookingID: "abc", Date: "22-01-2024"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Booking abc on 22-01-2024 was cancelled", body)
+}
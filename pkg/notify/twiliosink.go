@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+)
+
+// TwilioSMSSink sends the rendered Event as an SMS via Twilio.
+type TwilioSMSSink struct {
+	sms  clients.SMSService
+	from string
+	to   string
+}
+
+// NewTwilioSMSSink creates a TwilioSMSSink sending from and to via sms.
+func NewTwilioSMSSink(sms clients.SMSService, from, to string) *TwilioSMSSink {
+	return &TwilioSMSSink{sms: sms, from: from, to: to}
+}
+
+// Notify implements NotificationSink.
+func (s TwilioSMSSink) Notify(ctx context.Context, event Event) error {
+	body, err := RenderTemplate(templateOrDefault(nil, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	return s.sms.SendSmsWithContext(ctx, s.from, s.to, body, false)
+}
+
+// TwilioWhatsAppSink sends the rendered Event as a WhatsApp message via
+// Twilio, which addresses WhatsApp numbers with a "whatsapp:" prefix on
+// both the from and to numbers.
+type TwilioWhatsAppSink struct {
+	sms  clients.SMSService
+	from string
+	to   string
+}
+
+// NewTwilioWhatsAppSink creates a TwilioWhatsAppSink sending from and to
+// via sms.
+func NewTwilioWhatsAppSink(sms clients.SMSService, from, to string) *TwilioWhatsAppSink {
+	return &TwilioWhatsAppSink{sms: sms, from: from, to: to}
+}
+
+// Notify implements NotificationSink.
+func (s TwilioWhatsAppSink) Notify(ctx context.Context, event Event) error {
+	body, err := RenderTemplate(templateOrDefault(nil, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	return s.sms.SendSmsWithContext(ctx, whatsappAddress(s.from), whatsappAddress(s.to), body, false)
+}
+
+// TwilioVoiceSink places a phone call reading the rendered Event aloud via
+// Twilio Voice, for rules that want a louder alert than SMS/WhatsApp (see
+// clients.VoiceService).
+type TwilioVoiceSink struct {
+	voice clients.VoiceService
+	from  string
+	to    string
+}
+
+// NewTwilioVoiceSink creates a TwilioVoiceSink calling from and to via
+// voice.
+func NewTwilioVoiceSink(voice clients.VoiceService, from, to string) *TwilioVoiceSink {
+	return &TwilioVoiceSink{voice: voice, from: from, to: to}
+}
+
+// Notify implements NotificationSink.
+func (s TwilioVoiceSink) Notify(ctx context.Context, event Event) error {
+	body, err := RenderTemplate(templateOrDefault(nil, event.Kind), event)
+	if err != nil {
+		return err
+	}
+
+	return s.voice.PlaceCall(s.from, s.to, clients.SayTwiml(body), false)
+}
+
+// whatsappAddress prefixes number with "whatsapp:" as Twilio requires,
+// unless it's already prefixed.
+func whatsappAddress(number string) string {
+	if strings.HasPrefix(number, "whatsapp:") {
+		return number
+	}
+	return "whatsapp:" + number
+}
+
+// numberFromURL extracts the from/to numbers a twilio-sms://,
+// twilio-whatsapp://, or twilio-voice:// notifier URL encodes as host and
+// path, e.g. "twilio-sms://+123/+456" means from "+123" to "+456".
+func numberFromURL(u *url.URL) (from, to string) {
+	return u.Host, strings.TrimPrefix(u.Path, "/")
+}
+
+// twilioClientFromURL builds a TwilioClient for a twilio-sms:// or
+// twilio-whatsapp:// notifier URL. If the URL carries a verifyservicesid
+// query parameter, the client is gated by a VerificationStore (see
+// clients.VerificationService) backed by verificationstorepath (default
+// "verification_store.json"), so this notifier channel gets the same
+// opt-in protection against paging a mistyped number that the CLI's
+// `tee-sniper verify` flow and the legacy --verifyservicesid flag already
+// give the rest of the app.
+func twilioClientFromURL(u *url.URL) (*clients.TwilioClient, error) {
+	client := clients.NewTwilioClient()
+
+	serviceSid := u.Query().Get("verifyservicesid")
+	if serviceSid == "" {
+		return client, nil
+	}
+
+	storePath := u.Query().Get("verificationstorepath")
+	if storePath == "" {
+		storePath = "verification_store.json"
+	}
+
+	store, err := clients.NewVerificationStore(storePath)
+	if err != nil {
+		return nil, fmt.Errorf("notify: building verification store for %q: %w", u.Redacted(), err)
+	}
+
+	client.VerificationStore = store
+	return client, nil
+}
+
+func init() {
+	Register("twilio-sms", func(u *url.URL) (NotificationSink, error) {
+		from, to := numberFromURL(u)
+		client, err := twilioClientFromURL(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewTwilioSMSSink(client, from, to), nil
+	})
+
+	Register("twilio-whatsapp", func(u *url.URL) (NotificationSink, error) {
+		from, to := numberFromURL(u)
+		client, err := twilioClientFromURL(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewTwilioWhatsAppSink(client, from, to), nil
+	})
+
+	Register("twilio-voice", func(u *url.URL) (NotificationSink, error) {
+		from, to := numberFromURL(u)
+		return NewTwilioVoiceSink(clients.NewTwilioVoiceClient(), from, to), nil
+	})
+}
+
+var (
+	_ NotificationSink = TwilioSMSSink{}
+	_ NotificationSink = TwilioWhatsAppSink{}
+	_ NotificationSink = TwilioVoiceSink{}
+)
@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSMSService struct {
+	from string
+	to   string
+	body string
+	err  error
+}
+
+func (f *fakeSMSService) SendSms(from, to, body string, dryRun bool) error {
+	return f.SendSmsWithContext(context.Background(), from, to, body, dryRun)
+}
+
+func (f *fakeSMSService) SendSmsWithContext(ctx context.Context, from, to, body string, dryRun bool) error {
+	f.from = from
+	f.to = to
+	f.body = body
+	return f.err
+}
+
+func TestTwilioSMSSinkSendsRenderedBody(t *testing.T) {
+	sms := &fakeSMSService{}
+	sink := NewTwilioSMSSink(sms, "+1000", "+2000")
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled, BookingID: "abc", Date: "22-01-2024"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "+1000", sms.from)
+	assert.Equal(t, "+2000", sms.to)
+	assert.Equal(t, "Booking abc on 22-01-2024 was cancelled", sms.body)
+}
+
+func TestTwilioWhatsAppSinkPrefixesNumbers(t *testing.T) {
+	sms := &fakeSMSService{}
+	sink := NewTwilioWhatsAppSink(sms, "+1000", "+2000")
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled})
+
+	require.NoError(t, err)
+	assert.Equal(t, "whatsapp:+1000", sms.from)
+	assert.Equal(t, "whatsapp:+2000", sms.to)
+}
+
+func TestTwilioWhatsAppSinkDoesNotDoublePrefix(t *testing.T) {
+	sms := &fakeSMSService{}
+	sink := NewTwilioWhatsAppSink(sms, "whatsapp:+1000", "whatsapp:+2000")
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled})
+
+	require.NoError(t, err)
+	assert.Equal(t, "whatsapp:+1000", sms.from)
+	assert.Equal(t, "whatsapp:+2000", sms.to)
+}
+
+type fakeVoiceService struct {
+	from       string
+	to         string
+	twimlOrURL string
+	err        error
+}
+
+func (f *fakeVoiceService) PlaceCall(from, to, twimlOrURL string, dryRun bool) error {
+	f.from = from
+	f.to = to
+	f.twimlOrURL = twimlOrURL
+	return f.err
+}
+
+func TestTwilioVoiceSinkPlacesCallWithRenderedBody(t *testing.T) {
+	voice := &fakeVoiceService{}
+	sink := NewTwilioVoiceSink(voice, "+1000", "+2000")
+
+	err := sink.Notify(context.Background(), Event{Kind: EventCancelled, BookingID: "abc", Date: "22-01-2024"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "+1000", voice.from)
+	assert.Equal(t, "+2000", voice.to)
+	assert.Contains(t, voice.twimlOrURL, "Booking abc on 22-01-2024 was cancelled")
+}
+
+func TestTwilioClientFromURLWithoutVerifyServiceSidLeavesVerificationStoreNil(t *testing.T) {
+	u, err := url.Parse("twilio-sms://+1000/+2000")
+	require.NoError(t, err)
+
+	client, err := twilioClientFromURL(u)
+
+	require.NoError(t, err)
+	assert.Nil(t, client.VerificationStore)
+}
+
+func TestTwilioClientFromURLWithVerifyServiceSidSetsVerificationStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "verification_store.json")
+	u, err := url.Parse("twilio-sms://+1000/+2000?verifyservicesid=VAxxxx&verificationstorepath=" + url.QueryEscape(path))
+	require.NoError(t, err)
+
+	client, err := twilioClientFromURL(u)
+
+	require.NoError(t, err)
+	require.NotNil(t, client.VerificationStore)
+	assert.False(t, client.VerificationStore.IsVerified("+2000"))
+}
+
+func TestNumberFromURLSplitsHostAndPath(t *testing.T) {
+	u, err := url.Parse("twilio-sms://+1000/+2000")
+	require.NoError(t, err)
+
+	from, to := numberFromURL(u)
+
+	assert.Equal(t, "+1000", from)
+	assert.Equal(t, "+2000", to)
+}
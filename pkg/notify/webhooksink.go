@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookSink POSTs the raw Event as JSON to a webhook URL, for consumers
+// that want the structured fields rather than a rendered message.
+type WebhookSink struct {
+	httpClient *http.Client
+	url        string
+}
+
+// NewWebhookSink creates a WebhookSink that POSTs to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{httpClient: &http.Client{}, url: url}
+}
+
+// Notify implements NotificationSink.
+func (s WebhookSink) Notify(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+var _ NotificationSink = (*WebhookSink)(nil)
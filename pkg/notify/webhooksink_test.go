@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSinkPostsEventAsJSON(t *testing.T) {
+	var got Event
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+
+	err := sink.Notify(context.Background(), Event{Kind: EventBooked, Date: "22-01-2024"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, EventBooked, got.Kind)
+	assert.Equal(t, "22-01-2024", got.Date)
+}
+
+func TestWebhookSinkNonOKStatusIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+
+	err := sink.Notify(context.Background(), Event{Kind: EventBooked})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+// Package process provides a small harness for running a binary under
+// cmd/ as a set of concurrent, named Components, so each entrypoint is
+// just a State implementation handed to MakeApp.
+package process
+
+import (
+	"context"
+	"fmt"
+)
+
+// Component is a single named unit of work within a process - a queue
+// consumer, a scraping loop, a health server. Run should block until ctx
+// is cancelled or the Component can no longer make progress.
+type Component interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// State supplies the Components that make up one binary's process. Each
+// cmd/ entrypoint implements its own State (see pkg/scraper.State and
+// pkg/booker.State), wiring together whatever clients and Queue it needs,
+// and hands it to MakeApp.
+type State interface {
+	Provide() []Component
+}
+
+// App runs every Component a State provides concurrently.
+type App struct {
+	state State
+}
+
+// MakeApp creates an App that will run every Component state.Provide()
+// returns. This is the only thing a cmd/ entrypoint needs to call:
+//
+//	app := process.MakeApp(&scraper.State{...})
+//	log.Fatal(app.Run(ctx))
+func MakeApp(state State) *App {
+	return &App{state: state}
+}
+
+// Run starts every Component concurrently and returns once all of them
+// have returned. The first Component to return an error cancels the ctx
+// the others run under; that first error is returned, wrapped with the
+// name of the Component that produced it.
+func (a *App) Run(ctx context.Context) error {
+	components := a.state.Provide()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(components))
+	for _, c := range components {
+		c := c
+		go func() {
+			if err := c.Run(ctx); err != nil {
+				errs <- fmt.Errorf("%s: %w", c.Name(), err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+
+	var firstErr error
+	for range components {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	return firstErr
+}
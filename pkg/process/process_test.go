@@ -0,0 +1,107 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeComponent is a test double for Component.
+type fakeComponent struct {
+	name   string
+	runErr error
+	// block, when set, makes Run wait for ctx cancellation instead of
+	// returning immediately.
+	block bool
+}
+
+func (f *fakeComponent) Name() string { return f.name }
+
+func (f *fakeComponent) Run(ctx context.Context) error {
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.runErr
+}
+
+// fakeState is a test double for State.
+type fakeState struct {
+	components []Component
+}
+
+func (f *fakeState) Provide() []Component { return f.components }
+
+// TestAppRunReturnsNilWhenAllComponentsSucceed tests that Run returns nil
+// once every Component finishes without error.
+func TestAppRunReturnsNilWhenAllComponentsSucceed(t *testing.T) {
+	state := &fakeState{components: []Component{
+		&fakeComponent{name: "a"},
+		&fakeComponent{name: "b"},
+	}}
+
+	err := MakeApp(state).Run(context.Background())
+
+	assert.NoError(t, err)
+}
+
+// TestAppRunReturnsFirstComponentError tests that a failing Component's
+// error is returned, named after the Component.
+func TestAppRunReturnsFirstComponentError(t *testing.T) {
+	wantErr := errors.New("boom")
+	state := &fakeState{components: []Component{
+		&fakeComponent{name: "failing", runErr: wantErr},
+	}}
+
+	err := MakeApp(state).Run(context.Background())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, wantErr)
+	assert.Contains(t, err.Error(), "failing")
+}
+
+// TestAppRunCancelsRemainingComponentsOnError tests that a failing
+// Component causes the other, blocking Components to be cancelled rather
+// than leaving Run hanging forever.
+func TestAppRunCancelsRemainingComponentsOnError(t *testing.T) {
+	wantErr := errors.New("boom")
+	state := &fakeState{components: []Component{
+		&fakeComponent{name: "failing", runErr: wantErr},
+		&fakeComponent{name: "blocked", block: true},
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- MakeApp(state).Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, wantErr)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after a Component failed")
+	}
+}
+
+// TestAppRunRespectsParentContextCancellation tests that cancelling the
+// context passed to Run stops every blocking Component.
+func TestAppRunRespectsParentContextCancellation(t *testing.T) {
+	state := &fakeState{components: []Component{
+		&fakeComponent{name: "blocked", block: true},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- MakeApp(state).Run(ctx) }()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the parent context was cancelled")
+	}
+}
@@ -0,0 +1,31 @@
+package queue
+
+import "github.com/stebennett/tee-sniper/pkg/models"
+
+// Topic names used to coordinate the scraper and booker processes over a
+// Queue.
+const (
+	// TopicAvailability carries AvailabilityEvent messages.
+	TopicAvailability = "availability"
+
+	// TopicBookingIntent carries BookingIntent messages.
+	TopicBookingIntent = "booking-intent"
+)
+
+// AvailabilityEvent is published to TopicAvailability by a scraper
+// process whenever it finds bookable tee times for a course/date, so a
+// booker process can pick a slot and book it without re-scraping.
+type AvailabilityEvent struct {
+	Course string            `json:"course"`
+	Date   string            `json:"date"`
+	Slots  []models.TimeSlot `json:"slots"`
+}
+
+// BookingIntent is published to TopicBookingIntent to ask a booker
+// process to book a specific slot, typically in response to an
+// AvailabilityEvent.
+type BookingIntent struct {
+	Slot     models.TimeSlot `json:"slot"`
+	Partners []string        `json:"partners"`
+	User     string          `json:"user"`
+}
@@ -0,0 +1,148 @@
+// Package queue provides a minimal publish/subscribe abstraction so the
+// scraper and booker processes (see pkg/scraper and pkg/booker) can be
+// coordinated without either one holding a reference to the other.
+package queue
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Publish and Subscribe once the Queue has been
+// closed.
+var ErrClosed = errors.New("queue: closed")
+
+// subscriberBuffer bounds how far a subscriber can fall behind before
+// Publish blocks. It is small deliberately: a slow consumer should be
+// fixed, not masked by an unbounded buffer.
+const subscriberBuffer = 64
+
+// Queue is a publish/subscribe abstraction. Messages are opaque []byte
+// payloads - callers marshal/unmarshal their own message types (see
+// AvailabilityEvent and BookingIntent) - so a Queue implementation can be
+// backed by anything from Go channels to NATS or Redis Streams without
+// changing a single call site.
+type Queue interface {
+	// Publish delivers data to every current subscriber of topic. It does
+	// not persist messages for subscribers that join later.
+	Publish(topic string, data []byte) error
+
+	// Subscribe returns a channel of messages published to topic from
+	// this point on, and an unsubscribe function the caller must call
+	// once it is done reading.
+	Subscribe(topic string) (<-chan []byte, func(), error)
+
+	// Close shuts the Queue down, closing every subscriber channel.
+	Close() error
+}
+
+// subscriber pairs a subscriber's channel with a lock that's held across
+// both sends and close, so a Publish that has already snapshotted this
+// subscriber can't send on a channel that unsubscribe/Close is closing
+// concurrently.
+type subscriber struct {
+	mu     sync.Mutex
+	ch     chan []byte
+	closed bool
+}
+
+// EmbeddedQueue is an in-process Queue backed by Go channels. It
+// coordinates Components that live in the same process - it does not
+// cross process or host boundaries, so cmd/scraper and cmd/booker only
+// see each other's messages when run as Components of the same process.
+// Horizontally scaling the scraper/booker split across hosts requires a
+// networked Queue implementation (NATS, Redis Streams); EmbeddedQueue
+// exists so the split can be built and tested against this interface
+// today.
+type EmbeddedQueue struct {
+	mu          sync.Mutex
+	subscribers map[string][]*subscriber
+	closed      bool
+}
+
+// NewEmbeddedQueue creates a ready-to-use EmbeddedQueue.
+func NewEmbeddedQueue() *EmbeddedQueue {
+	return &EmbeddedQueue{
+		subscribers: make(map[string][]*subscriber),
+	}
+}
+
+// Publish implements Queue. It blocks if a subscriber's buffer is full,
+// applying backpressure to the publisher rather than dropping messages.
+func (q *EmbeddedQueue) Publish(topic string, data []byte) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrClosed
+	}
+	subs := append([]*subscriber(nil), q.subscribers[topic]...)
+	q.mu.Unlock()
+
+	for _, s := range subs {
+		s.mu.Lock()
+		if !s.closed {
+			s.ch <- data
+		}
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Subscribe implements Queue.
+func (q *EmbeddedQueue) Subscribe(topic string) (<-chan []byte, func(), error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, nil, ErrClosed
+	}
+
+	s := &subscriber{ch: make(chan []byte, subscriberBuffer)}
+	q.subscribers[topic] = append(q.subscribers[topic], s)
+
+	unsubscribe := func() {
+		q.mu.Lock()
+		subs := q.subscribers[topic]
+		for i, c := range subs {
+			if c == s {
+				q.subscribers[topic] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		q.mu.Unlock()
+
+		s.mu.Lock()
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	}
+
+	return s.ch, unsubscribe, nil
+}
+
+// Close implements Queue.
+func (q *EmbeddedQueue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	subscribers := q.subscribers
+	q.subscribers = nil
+	q.mu.Unlock()
+
+	for _, subs := range subscribers {
+		for _, s := range subs {
+			s.mu.Lock()
+			s.closed = true
+			close(s.ch)
+			s.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+var _ Queue = (*EmbeddedQueue)(nil)
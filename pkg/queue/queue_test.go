@@ -0,0 +1,170 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedQueueDeliversToSubscriber tests that a published message
+// reaches a subscriber of the same topic.
+func TestEmbeddedQueueDeliversToSubscriber(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	msgs, unsubscribe, err := q.Subscribe("topic")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	require.NoError(t, q.Publish("topic", []byte("hello")))
+
+	select {
+	case got := <-msgs:
+		assert.Equal(t, []byte("hello"), got)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+// TestEmbeddedQueueIgnoresOtherTopics tests that a subscriber only
+// receives messages published to its own topic.
+func TestEmbeddedQueueIgnoresOtherTopics(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	msgs, unsubscribe, err := q.Subscribe("topic-a")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	require.NoError(t, q.Publish("topic-b", []byte("hello")))
+
+	select {
+	case got := <-msgs:
+		t.Fatalf("unexpected message on topic-a: %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestEmbeddedQueueFansOutToMultipleSubscribers tests that every
+// subscriber of a topic receives a published message.
+func TestEmbeddedQueueFansOutToMultipleSubscribers(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	msgsA, unsubA, err := q.Subscribe("topic")
+	require.NoError(t, err)
+	defer unsubA()
+
+	msgsB, unsubB, err := q.Subscribe("topic")
+	require.NoError(t, err)
+	defer unsubB()
+
+	require.NoError(t, q.Publish("topic", []byte("hello")))
+
+	assert.Equal(t, []byte("hello"), <-msgsA)
+	assert.Equal(t, []byte("hello"), <-msgsB)
+}
+
+// TestEmbeddedQueueUnsubscribeStopsDelivery tests that a message
+// published after unsubscribe is not delivered.
+func TestEmbeddedQueueUnsubscribeStopsDelivery(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	msgs, unsubscribe, err := q.Subscribe("topic")
+	require.NoError(t, err)
+	unsubscribe()
+
+	_, ok := <-msgs
+	assert.False(t, ok, "channel should be closed after unsubscribe")
+}
+
+// TestEmbeddedQueueCloseClosesSubscriberChannels tests that Close closes
+// every open subscriber channel.
+func TestEmbeddedQueueCloseClosesSubscriberChannels(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	msgs, _, err := q.Subscribe("topic")
+	require.NoError(t, err)
+
+	require.NoError(t, q.Close())
+
+	_, ok := <-msgs
+	assert.False(t, ok, "channel should be closed")
+}
+
+// TestEmbeddedQueuePublishAfterCloseErrors tests that Publish refuses
+// once the Queue is closed.
+func TestEmbeddedQueuePublishAfterCloseErrors(t *testing.T) {
+	q := NewEmbeddedQueue()
+	require.NoError(t, q.Close())
+
+	assert.ErrorIs(t, q.Publish("topic", []byte("hello")), ErrClosed)
+}
+
+// TestEmbeddedQueueSubscribeAfterCloseErrors tests that Subscribe refuses
+// once the Queue is closed.
+func TestEmbeddedQueueSubscribeAfterCloseErrors(t *testing.T) {
+	q := NewEmbeddedQueue()
+	require.NoError(t, q.Close())
+
+	_, _, err := q.Subscribe("topic")
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+// TestEmbeddedQueuePublishRacesUnsubscribe hammers Publish concurrently
+// with unsubscribe to catch a send on a channel unsubscribe has closed.
+// Run with -race: a regression here panics with "send on closed channel"
+// instead of failing an assertion.
+func TestEmbeddedQueuePublishRacesUnsubscribe(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		msgs, unsubscribe, err := q.Subscribe("topic")
+		require.NoError(t, err)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range msgs {
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			unsubscribe()
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		_ = q.Publish("topic", []byte("hello"))
+	}
+
+	wg.Wait()
+}
+
+// TestEmbeddedQueuePublishRacesClose hammers Publish concurrently with
+// Close to catch a send on a channel Close has closed.
+func TestEmbeddedQueuePublishRacesClose(t *testing.T) {
+	q := NewEmbeddedQueue()
+
+	for i := 0; i < 50; i++ {
+		msgs, _, err := q.Subscribe("topic")
+		require.NoError(t, err)
+		go func() {
+			for range msgs {
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_ = q.Publish("topic", []byte("hello"))
+		}
+	}()
+
+	require.NoError(t, q.Close())
+	wg.Wait()
+}
@@ -0,0 +1,152 @@
+// Package scheduler fires a booking attempt once a day at a fixed time,
+// keeping a long-running process alive instead of relying on an external
+// cron entry plus a one-shot process - a club that opens its booking
+// window at a fixed time each morning is better served by a daemon that
+// fires within milliseconds of that window than by one that only wakes
+// up once a minute.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/utils"
+)
+
+// Trigger is the cron-style schedule Scheduler.Run fires on: every day at
+// TimeOfDay (HH:MM) in Location.
+type Trigger struct {
+	TimeOfDay string
+	Location  *time.Location
+}
+
+// Next returns the next time t fires strictly after after.
+func (t Trigger) Next(after time.Time) (time.Time, error) {
+	hour, minute, err := parseTimeOfDay(t.TimeOfDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc := t.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	local := after.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next, nil
+}
+
+func parseTimeOfDay(s string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("scheduler: invalid time-of-day %q, want HH:MM: %w", s, err)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// Scheduler fires a trigger's Action once a day until its Run's ctx is
+// cancelled. Clock and Sleep are both injected - mirroring
+// bookingfsm.Context's Now/Sleep fields - so tests can run a schedule
+// without waiting on a real clock.
+type Scheduler struct {
+	Clock utils.Clock
+	Sleep func(time.Duration)
+
+	// ShouldSkip, if set, is checked just before each fire; when it
+	// returns true, Run skips calling fn for that occurrence and moves on
+	// to the next one - see store.RunStore.SkipNext.
+	ShouldSkip func() (bool, error)
+}
+
+// Result is what Run reports back to onResult after each occurrence of
+// trigger, whether it fired or was skipped.
+type Result struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Skipped    bool
+	BookingID  string
+	Err        error
+}
+
+// Run blocks, waiting for each occurrence of trigger and calling fn when
+// it fires, until ctx is done. onResult, if set, is called after every
+// occurrence (fired or skipped) so a caller can persist run history - see
+// store.RunStore.RecordRun.
+func (s *Scheduler) Run(ctx context.Context, trigger Trigger, fn func() (string, error), onResult func(Result)) error {
+	for {
+		next, err := trigger.Next(s.now())
+		if err != nil {
+			return err
+		}
+
+		if err := s.waitUntil(ctx, next); err != nil {
+			return err
+		}
+
+		skip, err := s.shouldSkip()
+		if err != nil {
+			return err
+		}
+
+		startedAt := s.now()
+		result := Result{StartedAt: startedAt, Skipped: skip}
+
+		if !skip {
+			result.BookingID, result.Err = fn()
+		}
+
+		result.FinishedAt = s.now()
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+}
+
+func (s *Scheduler) shouldSkip() (bool, error) {
+	if s.ShouldSkip == nil {
+		return false, nil
+	}
+	return s.ShouldSkip()
+}
+
+func (s *Scheduler) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+func (s *Scheduler) sleep(d time.Duration) {
+	if s.Sleep == nil {
+		time.Sleep(d)
+		return
+	}
+	s.Sleep(d)
+}
+
+// waitUntil sleeps until target, re-checking ctx.Done() and the clock
+// between each sleep - a single real time.Sleep for the full remaining
+// duration wouldn't notice a cancelled ctx until it woke up, which is an
+// acceptable trade-off for a trigger that only fires once a day, but
+// would be a poor one for a tighter loop.
+func (s *Scheduler) waitUntil(ctx context.Context, target time.Time) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		remaining := target.Sub(s.now())
+		if remaining <= 0 {
+			return nil
+		}
+
+		s.sleep(remaining)
+	}
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerNextReturnsLaterTimeTodayWhenNotYetPassed(t *testing.T) {
+	trigger := Trigger{TimeOfDay: "09:00", Location: time.UTC}
+	after := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+
+	next, err := trigger.Next(after)
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestTriggerNextRollsOverToTomorrowWhenAlreadyPassed(t *testing.T) {
+	trigger := Trigger{TimeOfDay: "09:00", Location: time.UTC}
+	after := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	next, err := trigger.Next(after)
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestTriggerNextRejectsInvalidTimeOfDay(t *testing.T) {
+	trigger := Trigger{TimeOfDay: "not-a-time"}
+
+	_, err := trigger.Next(time.Now())
+
+	assert.Error(t, err)
+}
+
+// fakeClock lets a test advance "now" deterministically in lockstep with
+// Scheduler's injected Sleep, instead of waiting on a real clock.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestSchedulerRunFiresAtEachOccurrence(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)}
+	sched := &Scheduler{
+		Clock: clock,
+		Sleep: func(d time.Duration) { clock.now = clock.now.Add(d) },
+	}
+	trigger := Trigger{TimeOfDay: "09:00", Location: time.UTC}
+
+	var results []Result
+	ctx, cancel := context.WithCancel(context.Background())
+	fireCount := 0
+
+	err := sched.Run(ctx, trigger, func() (string, error) {
+		fireCount++
+		if fireCount == 3 {
+			cancel()
+		}
+		return "booking-123", nil
+	}, func(r Result) {
+		results = append(results, r)
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.False(t, r.Skipped)
+		assert.Equal(t, "booking-123", r.BookingID)
+	}
+}
+
+func TestSchedulerRunSkipsWhenShouldSkipReturnsTrue(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)}
+	calls := 0
+	sched := &Scheduler{
+		Clock:      clock,
+		Sleep:      func(d time.Duration) { clock.now = clock.now.Add(d) },
+		ShouldSkip: func() (bool, error) { calls++; return calls == 1, nil },
+	}
+	trigger := Trigger{TimeOfDay: "09:00", Location: time.UTC}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var results []Result
+	fired := 0
+
+	err := sched.Run(ctx, trigger, func() (string, error) {
+		fired++
+		cancel()
+		return "booking-abc", nil
+	}, func(r Result) {
+		results = append(results, r)
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Skipped)
+	assert.False(t, results[1].Skipped)
+	assert.Equal(t, 1, fired)
+}
+
+func TestSchedulerRunStopsImmediatelyOnAlreadyCancelledContext(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)}
+	sched := &Scheduler{Clock: clock, Sleep: func(d time.Duration) { clock.now = clock.now.Add(d) }}
+	trigger := Trigger{TimeOfDay: "09:00", Location: time.UTC}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := sched.Run(ctx, trigger, func() (string, error) {
+		t.Fatal("fn should not be called once ctx is already cancelled")
+		return "", nil
+	}, nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSchedulerRunPropagatesFireError(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)}
+	sched := &Scheduler{Clock: clock, Sleep: func(d time.Duration) { clock.now = clock.now.Add(d) }}
+	trigger := Trigger{TimeOfDay: "09:00", Location: time.UTC}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fireErr := errors.New("no availability")
+	var results []Result
+
+	err := sched.Run(ctx, trigger, func() (string, error) {
+		cancel()
+		return "", fireErr
+	}, func(r Result) {
+		results = append(results, r)
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, fireErr)
+}
@@ -0,0 +1,96 @@
+// Package scraper provides the process.State for the scraper half of the
+// queue-based scraper/booker split: it logs into the booking site and
+// publishes AvailabilityEvents, but never books anything itself, so it
+// never needs to hold Twilio or notifier credentials.
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/clients"
+	"github.com/stebennett/tee-sniper/pkg/process"
+	"github.com/stebennett/tee-sniper/pkg/queue"
+)
+
+// State wires together the dependencies a scraper process needs. Pass it
+// to process.MakeApp.
+type State struct {
+	BookingClient clients.BookingService
+	Queue         queue.Queue
+
+	Username string
+	Pin      string
+
+	// Course labels published AvailabilityEvents, for deployments running
+	// one scraper per course.
+	Course string
+
+	DateStr      string
+	PollInterval time.Duration
+}
+
+// Provide implements process.State.
+func (s *State) Provide() []process.Component {
+	return []process.Component{&poller{state: s}}
+}
+
+// poller is the single Component a scraper process runs.
+type poller struct {
+	state *State
+}
+
+func (p *poller) Name() string { return "scraper.poller" }
+
+// Run logs in once, then polls GetCourseAvailabilityContext on an
+// interval, publishing an AvailabilityEvent whenever it finds bookable
+// slots. The poller never books anything itself; pkg/booker consumes
+// BookingIntents rather than these events, and logs a failed booking
+// without retrying it.
+func (p *poller) Run(ctx context.Context) error {
+	s := p.state
+
+	if _, err := s.BookingClient.LoginContext(ctx, s.Username, s.Pin); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			p.pollOnce(ctx)
+		}
+	}
+}
+
+func (p *poller) pollOnce(ctx context.Context) {
+	s := p.state
+
+	slots, err := s.BookingClient.GetCourseAvailabilityContext(ctx, s.DateStr)
+	if err != nil {
+		log.Printf("scraper: failed to fetch availability: %s", err.Error())
+		return
+	}
+
+	if len(slots) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(queue.AvailabilityEvent{Course: s.Course, Date: s.DateStr, Slots: slots})
+	if err != nil {
+		log.Printf("scraper: failed to marshal availability event: %s", err.Error())
+		return
+	}
+
+	if err := s.Queue.Publish(queue.TopicAvailability, data); err != nil {
+		log.Printf("scraper: failed to publish availability event: %s", err.Error())
+	}
+}
+
+var _ process.Component = (*poller)(nil)
@@ -0,0 +1,128 @@
+// Package store persists the tee-sniper daemon's booking run history to a
+// local BoltDB file, so `tee-sniper daemon list-runs` can report past
+// outcomes - and `daemon skip-next` can record an admin action - across
+// process restarts, without standing up a separate database.
+package store
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	runsBucket  = []byte("runs")
+	metaBucket  = []byte("meta")
+	skipNextKey = []byte("skip_next")
+)
+
+// RunRecord is one outcome of App.Run, persisted by RunStore.RecordRun.
+type RunRecord struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Success    bool      `json:"success"`
+	BookingID  string    `json:"booking_id,omitempty"`
+	Err        string    `json:"err,omitempty"`
+}
+
+// RunStore persists RunRecords (and the skip-next admin flag) in a BoltDB
+// file, so a daemon's run history survives a process restart and can be
+// inspected without scraping logs.
+type RunStore struct {
+	db *bolt.DB
+}
+
+// NewRunStore opens (creating if necessary) the BoltDB file at path.
+func NewRunStore(path string) (*RunStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(runsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(metaBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &RunStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *RunStore) Close() error {
+	return s.db.Close()
+}
+
+// RecordRun persists run, keyed by its StartedAt so ListRuns can return
+// runs in chronological order.
+func (s *RunStore) RecordRun(run RunRecord) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).Put([]byte(run.StartedAt.Format(time.RFC3339Nano)), data)
+	})
+}
+
+// ListRuns returns every persisted RunRecord, oldest first.
+func (s *RunStore) ListRuns() ([]RunRecord, error) {
+	var runs []RunRecord
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).ForEach(func(k, v []byte) error {
+			var run RunRecord
+			if err := json.Unmarshal(v, &run); err != nil {
+				return err
+			}
+			runs = append(runs, run)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].StartedAt.Before(runs[j].StartedAt) })
+	return runs, nil
+}
+
+// SetSkipNext marks (or, when skip is false, clears) that the daemon's
+// next scheduled trigger should be skipped without firing a booking
+// attempt - see scheduler.Scheduler.ShouldSkip.
+func (s *RunStore) SetSkipNext(skip bool) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(metaBucket)
+		if skip {
+			return b.Put(skipNextKey, []byte{1})
+		}
+		return b.Delete(skipNextKey)
+	})
+}
+
+// SkipNext reports whether the next scheduled trigger should be skipped,
+// clearing the flag so only that one trigger is affected - see
+// SetSkipNext.
+func (s *RunStore) SkipNext() (bool, error) {
+	var skip bool
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(metaBucket)
+		skip = b.Get(skipNextKey) != nil
+		if skip {
+			return b.Delete(skipNextKey)
+		}
+		return nil
+	})
+
+	return skip, err
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStoreListRunsEmptyByDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_history.db")
+
+	s, err := NewRunStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	runs, err := s.ListRuns()
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestRunStoreRecordRunThenListRunsReturnsChronologicalOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_history.db")
+
+	s, err := NewRunStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	older := RunRecord{StartedAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), Success: true, BookingID: "booking-1"}
+	newer := RunRecord{StartedAt: time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), Success: false, Err: "no availability"}
+
+	require.NoError(t, s.RecordRun(newer))
+	require.NoError(t, s.RecordRun(older))
+
+	runs, err := s.ListRuns()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "booking-1", runs[0].BookingID)
+	assert.Equal(t, "no availability", runs[1].Err)
+}
+
+func TestRunStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_history.db")
+
+	s, err := NewRunStore(path)
+	require.NoError(t, err)
+	require.NoError(t, s.RecordRun(RunRecord{StartedAt: time.Now(), Success: true, BookingID: "booking-1"}))
+	require.NoError(t, s.Close())
+
+	reopened, err := NewRunStore(path)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	runs, err := reopened.ListRuns()
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "booking-1", runs[0].BookingID)
+}
+
+func TestRunStoreSkipNextFalseByDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_history.db")
+
+	s, err := NewRunStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	skip, err := s.SkipNext()
+	require.NoError(t, err)
+	assert.False(t, skip)
+}
+
+func TestRunStoreSetSkipNextIsConsumedOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_history.db")
+
+	s, err := NewRunStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.SetSkipNext(true))
+
+	skip, err := s.SkipNext()
+	require.NoError(t, err)
+	assert.True(t, skip)
+
+	skip, err = s.SkipNext()
+	require.NoError(t, err)
+	assert.False(t, skip, "SkipNext should clear the flag after reporting it once")
+}
@@ -0,0 +1,176 @@
+package teetimes
+
+import (
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+)
+
+// ScoringContext carries the per-run inputs a Scorer needs beyond the slot
+// itself: the date being booked, and the user's declared preferences - see
+// config.Config's preferred-time/preferred-days/preferred-courses flags.
+type ScoringContext struct {
+	DateStr             string
+	PreferredTime       string
+	PreferredDaysOfWeek []time.Weekday
+	PreferredCourseIDs  []string
+	RecentlyBookedTimes []string
+}
+
+// Scorer assigns a TimeSlot a score under sctx; PickBestTime picks the
+// slot with the highest one. Implementations are composable via
+// WeightedSumScorer, so a user's preferences can be combined without any
+// one Scorer needing to know about the others.
+type Scorer interface {
+	Score(slot models.TimeSlot, sctx ScoringContext) float64
+}
+
+// preferredTimeScoreCapMinutes bounds how many minutes of difference fully
+// saturate PreferredTimeScorer's score at -1. Without a cap, an unbounded
+// minutes-based penalty (routinely tens to hundreds) drowns out
+// DayOfWeekScorer/CourseIDScorer's ±1 range under WeightedSumScorer's equal
+// default weighting, so setting --preferred-days or --preferred-courses
+// alongside --preferred-time would otherwise have no effect on the chosen
+// slot.
+const preferredTimeScoreCapMinutes = 240.0
+
+// PreferredTimeScorer scores a slot by how close it is to
+// sctx.PreferredTime (HH:MM): 0 when they match, falling off linearly to -1
+// at preferredTimeScoreCapMinutes or more of difference. Scores 0 (a no-op)
+// when PreferredTime is unset or either time fails to parse.
+type PreferredTimeScorer struct{}
+
+// Score implements Scorer.
+func (PreferredTimeScorer) Score(slot models.TimeSlot, sctx ScoringContext) float64 {
+	if sctx.PreferredTime == "" {
+		return 0
+	}
+	diff, ok := minutesBetween(slot.Time, sctx.PreferredTime)
+	if !ok {
+		return 0
+	}
+	if float64(diff) >= preferredTimeScoreCapMinutes {
+		return -1
+	}
+	return -float64(diff) / preferredTimeScoreCapMinutes
+}
+
+// DayOfWeekScorer scores 1 when sctx.DateStr's weekday is among
+// sctx.PreferredDaysOfWeek, 0 otherwise - a no-op weight when the user
+// hasn't expressed a day-of-week preference.
+type DayOfWeekScorer struct{}
+
+// Score implements Scorer.
+func (DayOfWeekScorer) Score(_ models.TimeSlot, sctx ScoringContext) float64 {
+	if len(sctx.PreferredDaysOfWeek) == 0 {
+		return 0
+	}
+	d, err := time.Parse("02-01-2006", sctx.DateStr)
+	if err != nil {
+		return 0
+	}
+	for _, preferred := range sctx.PreferredDaysOfWeek {
+		if d.Weekday() == preferred {
+			return 1
+		}
+	}
+	return 0
+}
+
+// CourseIDScorer scores 1 when the slot's course (read from its
+// BookingForm's "course" field, the same place BookingClient reads it for
+// metrics/events) is among sctx.PreferredCourseIDs, 0 otherwise.
+type CourseIDScorer struct{}
+
+// Score implements Scorer.
+func (CourseIDScorer) Score(slot models.TimeSlot, sctx ScoringContext) float64 {
+	if len(sctx.PreferredCourseIDs) == 0 {
+		return 0
+	}
+	courseID := slot.BookingForm["course"]
+	for _, id := range sctx.PreferredCourseIDs {
+		if id == courseID {
+			return 1
+		}
+	}
+	return 0
+}
+
+// RecencyPenaltyScorer scores -1 for a slot within Window of any time in
+// sctx.RecentlyBookedTimes, so a scoring pipeline can avoid repeatedly
+// landing on the same tee time - e.g. fed from a historical record of past
+// bookings for this course the caller threads into
+// ScoringContext.RecentlyBookedTimes.
+type RecencyPenaltyScorer struct {
+	Window time.Duration
+}
+
+// Score implements Scorer.
+func (s RecencyPenaltyScorer) Score(slot models.TimeSlot, sctx ScoringContext) float64 {
+	for _, recent := range sctx.RecentlyBookedTimes {
+		diff, ok := minutesBetween(slot.Time, recent)
+		if ok && time.Duration(diff)*time.Minute <= s.Window {
+			return -1
+		}
+	}
+	return 0
+}
+
+// WeightedSumScorer scores a slot as the weighted sum of each of Scorers'
+// own scores. Weights[i] applies to Scorers[i]; a missing weight (Weights
+// shorter than Scorers) defaults to 1.
+type WeightedSumScorer struct {
+	Scorers []Scorer
+	Weights []float64
+}
+
+// Score implements Scorer.
+func (w WeightedSumScorer) Score(slot models.TimeSlot, sctx ScoringContext) float64 {
+	var total float64
+	for i, scorer := range w.Scorers {
+		weight := 1.0
+		if i < len(w.Weights) {
+			weight = w.Weights[i]
+		}
+		total += weight * scorer.Score(slot, sctx)
+	}
+	return total
+}
+
+// PickBestTime returns the highest-scoring slot in slots under scorer,
+// breaking ties in favour of the earlier slot (slots is expected
+// pre-sorted ascending - see SortTimesAscending). It replaces the
+// uniform-random PickRandomTime, so a user's declared preferences (see
+// ScoringContext) actually matter once more than one slot passes the
+// window filter.
+func PickBestTime(slots []models.TimeSlot, scorer Scorer, sctx ScoringContext) (models.TimeSlot, error) {
+	if len(slots) == 0 {
+		return models.TimeSlot{}, ErrNoTimeSlotsAvailable
+	}
+
+	best := slots[0]
+	bestScore := scorer.Score(best, sctx)
+	for _, slot := range slots[1:] {
+		score := scorer.Score(slot, sctx)
+		if score > bestScore {
+			best = slot
+			bestScore = score
+		}
+	}
+	return best, nil
+}
+
+// minutesBetween returns the absolute number of minutes between two HH:MM
+// times, and false if either fails to parse.
+func minutesBetween(a, b string) (int, bool) {
+	ta, errA := time.Parse("15:04", a)
+	tb, errB := time.Parse("15:04", b)
+	if errA != nil || errB != nil {
+		return 0, false
+	}
+	diff := int(ta.Sub(tb).Minutes())
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff, true
+}
@@ -0,0 +1,148 @@
+package teetimes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stebennett/tee-sniper/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickBestTimeEmptySlice(t *testing.T) {
+	_, err := PickBestTime([]models.TimeSlot{}, PreferredTimeScorer{}, ScoringContext{})
+	assert.ErrorIs(t, err, ErrNoTimeSlotsAvailable)
+}
+
+func TestPickBestTimePicksHighestScoringSlot(t *testing.T) {
+	slots := []models.TimeSlot{
+		{Time: "09:00"},
+		{Time: "12:00"},
+		{Time: "15:00"},
+	}
+
+	result, err := PickBestTime(slots, PreferredTimeScorer{}, ScoringContext{PreferredTime: "12:30"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "12:00", result.Time)
+}
+
+func TestPickBestTimeBreaksTiesByEarlierSlot(t *testing.T) {
+	slots := []models.TimeSlot{
+		{Time: "09:00"},
+		{Time: "10:00"},
+	}
+
+	result, err := PickBestTime(slots, PreferredTimeScorer{}, ScoringContext{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "09:00", result.Time)
+}
+
+func TestPreferredTimeScorerNoPreferenceScoresZero(t *testing.T) {
+	scorer := PreferredTimeScorer{}
+	assert.Equal(t, 0.0, scorer.Score(models.TimeSlot{Time: "09:00"}, ScoringContext{}))
+}
+
+func TestPreferredTimeScorerPrefersCloserSlot(t *testing.T) {
+	scorer := PreferredTimeScorer{}
+	sctx := ScoringContext{PreferredTime: "12:00"}
+
+	closeScore := scorer.Score(models.TimeSlot{Time: "12:15"}, sctx)
+	farScore := scorer.Score(models.TimeSlot{Time: "14:00"}, sctx)
+
+	assert.Greater(t, closeScore, farScore)
+}
+
+func TestDayOfWeekScorerScoresOneOnPreferredDay(t *testing.T) {
+	scorer := DayOfWeekScorer{}
+	sctx := ScoringContext{DateStr: "17-01-2024", PreferredDaysOfWeek: []time.Weekday{time.Wednesday}}
+
+	assert.Equal(t, 1.0, scorer.Score(models.TimeSlot{}, sctx))
+}
+
+func TestDayOfWeekScorerScoresZeroOnNonPreferredDay(t *testing.T) {
+	scorer := DayOfWeekScorer{}
+	sctx := ScoringContext{DateStr: "18-01-2024", PreferredDaysOfWeek: []time.Weekday{time.Wednesday}}
+
+	assert.Equal(t, 0.0, scorer.Score(models.TimeSlot{}, sctx))
+}
+
+func TestDayOfWeekScorerNoPreferenceScoresZero(t *testing.T) {
+	scorer := DayOfWeekScorer{}
+	assert.Equal(t, 0.0, scorer.Score(models.TimeSlot{}, ScoringContext{DateStr: "17-01-2024"}))
+}
+
+func TestCourseIDScorerScoresOneForPreferredCourse(t *testing.T) {
+	scorer := CourseIDScorer{}
+	slot := models.TimeSlot{BookingForm: map[string]string{"course": "course-1"}}
+	sctx := ScoringContext{PreferredCourseIDs: []string{"course-1", "course-2"}}
+
+	assert.Equal(t, 1.0, scorer.Score(slot, sctx))
+}
+
+func TestCourseIDScorerScoresZeroForOtherCourse(t *testing.T) {
+	scorer := CourseIDScorer{}
+	slot := models.TimeSlot{BookingForm: map[string]string{"course": "course-3"}}
+	sctx := ScoringContext{PreferredCourseIDs: []string{"course-1", "course-2"}}
+
+	assert.Equal(t, 0.0, scorer.Score(slot, sctx))
+}
+
+func TestRecencyPenaltyScorerPenalizesSlotsWithinWindow(t *testing.T) {
+	scorer := RecencyPenaltyScorer{Window: 30 * time.Minute}
+	sctx := ScoringContext{RecentlyBookedTimes: []string{"10:00"}}
+
+	assert.Equal(t, -1.0, scorer.Score(models.TimeSlot{Time: "10:15"}, sctx))
+	assert.Equal(t, 0.0, scorer.Score(models.TimeSlot{Time: "11:00"}, sctx))
+}
+
+func TestWeightedSumScorerCombinesScores(t *testing.T) {
+	scorer := WeightedSumScorer{
+		Scorers: []Scorer{CourseIDScorer{}, RecencyPenaltyScorer{Window: 30 * time.Minute}},
+		Weights: []float64{2, 1},
+	}
+	slot := models.TimeSlot{Time: "10:15", BookingForm: map[string]string{"course": "course-1"}}
+	sctx := ScoringContext{PreferredCourseIDs: []string{"course-1"}, RecentlyBookedTimes: []string{"10:00"}}
+
+	// 2*1 (preferred course) + 1*-1 (recency penalty) = 1
+	assert.Equal(t, 1.0, scorer.Score(slot, sctx))
+}
+
+func TestWeightedSumScorerDefaultsMissingWeightToOne(t *testing.T) {
+	scorer := WeightedSumScorer{Scorers: []Scorer{CourseIDScorer{}}}
+	slot := models.TimeSlot{BookingForm: map[string]string{"course": "course-1"}}
+	sctx := ScoringContext{PreferredCourseIDs: []string{"course-1"}}
+
+	assert.Equal(t, 1.0, scorer.Score(slot, sctx))
+}
+
+func TestPreferredTimeScorerScoreIsBounded(t *testing.T) {
+	scorer := PreferredTimeScorer{}
+	sctx := ScoringContext{PreferredTime: "09:00"}
+
+	// Even a slot many hours from the preferred time must not swamp a
+	// DayOfWeekScorer/CourseIDScorer's ±1 contribution under
+	// WeightedSumScorer's equal default weighting.
+	farScore := scorer.Score(models.TimeSlot{Time: "18:00"}, sctx)
+	assert.GreaterOrEqual(t, farScore, -1.0)
+}
+
+func TestWeightedSumScorerHonoursCoursePreferenceAlongsidePreferredTime(t *testing.T) {
+	scorer := WeightedSumScorer{
+		Scorers: []Scorer{PreferredTimeScorer{}, CourseIDScorer{}},
+	}
+	sctx := ScoringContext{
+		PreferredTime:      "09:00",
+		PreferredCourseIDs: []string{"course-1"},
+	}
+
+	// Before this was bounded, a 30-minute difference from PreferredTime
+	// (-30) always beat CourseIDScorer's +1 bonus, so the course
+	// preference was silently ignored for any slot that wasn't an exact
+	// time match.
+	onPreferredCourse := models.TimeSlot{Time: "09:30", BookingForm: map[string]string{"course": "course-1"}}
+	exactTimeMatchOtherCourse := models.TimeSlot{Time: "09:00", BookingForm: map[string]string{"course": "course-2"}}
+
+	assert.Greater(t, scorer.Score(onPreferredCourse, sctx), scorer.Score(exactTimeMatchOtherCourse, sctx))
+}
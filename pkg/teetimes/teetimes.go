@@ -2,7 +2,6 @@ package teetimes
 
 import (
 	"errors"
-	"math/rand"
 	"sort"
 
 	"github.com/stebennett/tee-sniper/pkg/models"
@@ -35,11 +34,3 @@ func FilterBetweenTimes(slots []models.TimeSlot, startTime string, endTime strin
 	}
 	return
 }
-
-func PickRandomTime(slots []models.TimeSlot) (models.TimeSlot, error) {
-	if len(slots) == 0 {
-		return models.TimeSlot{}, ErrNoTimeSlotsAvailable
-	}
-	index := rand.Intn(len(slots))
-	return slots[index], nil
-}
@@ -106,39 +106,6 @@ func TestFilterTimesBetweenExcludeStart(t *testing.T) {
 	assert.Equal(t, "15:00", sorted[1].Time)
 }
 
-// PickRandomTime tests
-
-func TestPickRandomTimeEmptySlice(t *testing.T) {
-	result := PickRandomTime([]models.TimeSlot{})
-	assert.Equal(t, models.TimeSlot{}, result)
-}
-
-func TestPickRandomTimeSingleItem(t *testing.T) {
-	slot := models.TimeSlot{Time: "10:00", CanBook: true}
-	result := PickRandomTime([]models.TimeSlot{slot})
-	assert.Equal(t, slot, result)
-}
-
-func TestPickRandomTimeMultipleItems(t *testing.T) {
-	slots := []models.TimeSlot{
-		{Time: "09:00", CanBook: true},
-		{Time: "10:00", CanBook: true},
-		{Time: "11:00", CanBook: true},
-	}
-
-	result := PickRandomTime(slots)
-
-	// Verify the result is one of the input slots
-	found := false
-	for _, s := range slots {
-		if s.Time == result.Time {
-			found = true
-			break
-		}
-	}
-	assert.True(t, found, "PickRandomTime should return an item from the input slice")
-}
-
 // SortTimesAscending edge case tests
 
 func TestSortTimesAscendingEmpty(t *testing.T) {